@@ -74,8 +74,8 @@ log_info "Test application built and loaded"
 # Step 3: Install CRDs (skip if in-cluster operator mode)
 if [ "$USE_IN_CLUSTER_OPERATOR" != "1" ] && [ "$USE_IN_CLUSTER_OPERATOR" != "true" ]; then
     log_info "Step 3: Installing CRDs..."
-    kubectl apply -f operator/config/crd/remediation-crd.yaml
-    kubectl wait --for condition=established --timeout=60s crd/remediations.k8shealer.k8s-healer.io
+    kubectl apply -f operator/config/crd/
+    kubectl wait --for condition=established --timeout=60s crd/remediations.k8shealer.k8s-healer.io crd/maintenancewindows.k8shealer.k8s-healer.io crd/remediationjobtemplates.k8shealer.k8s-healer.io
     log_info "CRDs installed successfully"
 fi
 
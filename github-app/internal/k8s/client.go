@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -13,6 +14,10 @@ import (
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/github-app/pkg/api/v1alpha1"
 )
 
+// ConditionMaintenanceWindow is set false by the operator once it has checked
+// that no MaintenanceWindow holds a Remediation
+const ConditionMaintenanceWindow = "MaintenanceWindow"
+
 // Client wraps a Kubernetes client
 type Client struct {
 	client.Client
@@ -67,10 +72,14 @@ func (c *Client) ListPendingRemediations(ctx context.Context, namespace string)
 		return nil, fmt.Errorf("failed to list remediations: %w", err)
 	}
 
-	// Filter for pending with GitHub enabled
+	// Filter for pending with GitHub enabled. Pod targets are skipped until the
+	// operator has resolved them to their owning workload, and all are skipped
+	// until it has found no maintenance window holding them.
 	pending := &k8shealerv1alpha1.RemediationList{}
 	for _, item := range list.Items {
 		if item.Status.Phase == k8shealerv1alpha1.RemediationPhasePending &&
+			meta.IsStatusConditionFalse(item.Status.Conditions, ConditionMaintenanceWindow) &&
+			item.Spec.Target.Kind != "Pod" &&
 			item.Spec.GitHub != nil &&
 			item.Spec.GitHub.Enabled {
 			pending.Items = append(pending.Items, item)
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/github-app/pkg/api/v1alpha1"
+)
+
+func TestListPendingRemediations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	windowChecked := metav1.Condition{Type: ConditionMaintenanceWindow, Status: metav1.ConditionFalse, Reason: "NoWindowActive"}
+	windowHolds := metav1.Condition{Type: ConditionMaintenanceWindow, Status: metav1.ConditionTrue, Reason: "Freeze"}
+	newRemediation := func(name, kind string, phase k8shealerv1alpha1.RemediationPhase, conditions ...metav1.Condition) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: kind, Name: "api", Namespace: "default"},
+				GitHub: &k8shealerv1alpha1.GitHubConfig{Enabled: true},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: phase, Conditions: conditions},
+		}
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		newRemediation("ready", "Deployment", k8shealerv1alpha1.RemediationPhasePending, windowChecked),
+		newRemediation("not-analyzed", "Deployment", k8shealerv1alpha1.RemediationPhasePending),
+		newRemediation("held", "Deployment", k8shealerv1alpha1.RemediationPhasePending, windowHolds),
+		newRemediation("on-hold", "Deployment", k8shealerv1alpha1.RemediationPhaseOnHold, windowHolds),
+		newRemediation("pod", "Pod", k8shealerv1alpha1.RemediationPhasePending, windowChecked),
+	).Build()
+	c := &Client{Client: cl, scheme: scheme}
+
+	list, err := c.ListPendingRemediations(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListPendingRemediations failed: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Name != "ready" {
+		var names []string
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		t.Errorf("Expected only the remediation cleared of maintenance windows, got %v", names)
+	}
+}
@@ -3,6 +3,7 @@ package remediation
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -211,6 +212,13 @@ This PR was automatically generated in response to a %s alert in %s.
 	case k8shealerv1alpha1.ActionTypeIncreaseMemory:
 		body += fmt.Sprintf("- Increase memory limits by %s%%\n", remediation.Spec.Action.Params["memoryIncreasePercent"])
 		body += fmt.Sprintf("- Maximum memory: %s\n", remediation.Spec.Action.Params["maxMemory"])
+	case k8shealerv1alpha1.ActionTypeIncreaseCPU:
+		if remove, _ := strconv.ParseBool(remediation.Spec.Action.Params["removeCPULimit"]); remove {
+			body += "- Remove the CPU limit\n"
+		} else {
+			body += fmt.Sprintf("- Increase CPU limit by %s%%\n", remediation.Spec.Action.Params["cpuIncreasePercent"])
+			body += fmt.Sprintf("- Maximum CPU: %s\n", remediation.Spec.Action.Params["maxCPU"])
+		}
 	case k8shealerv1alpha1.ActionTypeScaleUp:
 		body += fmt.Sprintf("- Scale up by %s%%\n", remediation.Spec.Action.Params["scaleUpPercent"])
 		body += fmt.Sprintf("- Maximum replicas: %s\n", remediation.Spec.Action.Params["maxReplicas"])
@@ -2,6 +2,7 @@ package yaml
 
 import (
 	"fmt"
+	"strconv"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -43,6 +44,10 @@ func (p *Patcher) PatchManifest(yamlContent string, remediation *k8shealerv1alph
 		if err := p.patchIncreaseMemory(obj, remediation); err != nil {
 			return "", fmt.Errorf("failed to patch memory: %w", err)
 		}
+	case k8shealerv1alpha1.ActionTypeIncreaseCPU:
+		if err := p.patchIncreaseCPU(obj, remediation); err != nil {
+			return "", fmt.Errorf("failed to patch CPU: %w", err)
+		}
 	case k8shealerv1alpha1.ActionTypeScaleUp:
 		if err := p.patchScaleUp(obj, remediation); err != nil {
 			return "", fmt.Errorf("failed to patch scale: %w", err)
@@ -158,6 +163,103 @@ func (p *Patcher) patchIncreaseMemory(obj runtime.Object, remediation *k8shealer
 	return nil
 }
 
+func (p *Patcher) patchIncreaseCPU(obj runtime.Object, remediation *k8shealerv1alpha1.Remediation) error {
+	// Parse parameters
+	increasePercent := 50
+	if val, ok := remediation.Spec.Action.Params["cpuIncreasePercent"]; ok {
+		fmt.Sscanf(val, "%d", &increasePercent)
+	}
+
+	roundTo := resource.MustParse("100m")
+	if val, ok := remediation.Spec.Action.Params["cpuRoundTo"]; ok {
+		if parsed, err := resource.ParseQuantity(val); err == nil {
+			roundTo = parsed
+		}
+	}
+
+	maxCPU := resource.MustParse("4")
+	if val, ok := remediation.Spec.Action.Params["maxCPU"]; ok {
+		if parsed, err := resource.ParseQuantity(val); err == nil {
+			maxCPU = parsed
+		}
+	}
+
+	removeLimit, _ := strconv.ParseBool(remediation.Spec.Action.Params["removeCPULimit"])
+
+	containerName := remediation.Spec.Target.Container
+
+	// Get containers based on object type
+	var containers *[]corev1.Container
+	switch v := obj.(type) {
+	case *appsv1.Deployment:
+		containers = &v.Spec.Template.Spec.Containers
+	case *appsv1.StatefulSet:
+		containers = &v.Spec.Template.Spec.Containers
+	case *appsv1.DaemonSet:
+		containers = &v.Spec.Template.Spec.Containers
+	default:
+		return fmt.Errorf("unsupported object type: %T", obj)
+	}
+
+	// Find and patch container
+	containerIndex := -1
+	if containerName == "" && len(*containers) == 1 {
+		containerIndex = 0
+	} else {
+		for i, c := range *containers {
+			if c.Name == containerName {
+				containerIndex = i
+				break
+			}
+		}
+	}
+
+	if containerIndex == -1 {
+		return fmt.Errorf("container %s not found", containerName)
+	}
+
+	container := &(*containers)[containerIndex]
+
+	// Throttling only happens under a CPU limit
+	currentCPU, ok := container.Resources.Limits[corev1.ResourceCPU]
+	if !ok || currentCPU.IsZero() {
+		return fmt.Errorf("container %s has no CPU limit", container.Name)
+	}
+
+	if removeLimit {
+		// A missing request defaults to the limit. Dropping a limit equal to the
+		// request would move the pod out of the Guaranteed QoS class.
+		if request, ok := container.Resources.Requests[corev1.ResourceCPU]; !ok || request.Cmp(currentCPU) == 0 {
+			return fmt.Errorf("container %s has a CPU request equal to its limit; removing the limit would change its QoS class", container.Name)
+		}
+		delete(container.Resources.Limits, corev1.ResourceCPU)
+		return nil
+	}
+
+	if currentCPU.Cmp(maxCPU) >= 0 {
+		return fmt.Errorf("CPU limit %s is already at or above maxCPU %s", currentCPU.String(), maxCPU.String())
+	}
+
+	// Calculate new CPU, rounded up to roundTo
+	newMilli := (currentCPU.MilliValue()*int64(100+increasePercent) + 99) / 100
+	if step := roundTo.MilliValue(); step > 0 {
+		newMilli = ((newMilli + step - 1) / step) * step
+	}
+
+	newCPU := *resource.NewMilliQuantity(newMilli, resource.DecimalSI)
+	if newCPU.Cmp(maxCPU) > 0 {
+		newCPU = maxCPU
+	}
+
+	// Keep a request equal to the limit so the QoS class does not change
+	if request, ok := container.Resources.Requests[corev1.ResourceCPU]; ok && request.Cmp(currentCPU) == 0 {
+		container.Resources.Requests[corev1.ResourceCPU] = newCPU
+	}
+	container.Resources.Limits[corev1.ResourceCPU] = newCPU
+
+	return nil
+}
+
 func (p *Patcher) patchScaleUp(obj runtime.Object, remediation *k8shealerv1alpha1.Remediation) error {
 	scalePercent := 50
 	if val, ok := remediation.Spec.Action.Params["scaleUpPercent"]; ok {
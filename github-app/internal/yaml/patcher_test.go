@@ -127,3 +127,117 @@ spec:
 		t.Errorf("Expected replicas to be increased, got:\n%s", patchedYAML)
 	}
 }
+
+func TestPatchManifest_IncreaseCPU(t *testing.T) {
+	inputYAML := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-app
+  namespace: default
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      containers:
+      - name: app
+        image: nginx:latest
+        resources:
+          limits:
+            cpu: 500m
+            memory: 256Mi
+          requests:
+            cpu: 500m
+            memory: 128Mi
+`
+
+	// Same Deployment with a CPU request below the limit
+	burstableYAML := strings.Replace(inputYAML, "requests:\n            cpu: 500m", "requests:\n            cpu: 200m", 1)
+
+	tests := []struct {
+		name        string
+		input       string
+		params      map[string]string
+		expectError bool
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name:     "raises limit and equal request",
+			params:   map[string]string{"cpuIncreasePercent": "50", "cpuRoundTo": "100m", "maxCPU": "2"},
+			contains: []string{"cpu: 800m"},
+			excludes: []string{"cpu: 500m"},
+		},
+		{
+			name:     "capped at max",
+			params:   map[string]string{"cpuIncreasePercent": "100", "maxCPU": "600m"},
+			contains: []string{"cpu: 600m"},
+		},
+		{
+			name:     "removes limit",
+			input:    burstableYAML,
+			params:   map[string]string{"removeCPULimit": "true"},
+			contains: []string{"cpu: 200m"},
+			excludes: []string{"cpu: 500m"},
+		},
+		{
+			name:        "keeps limit equal to request",
+			params:      map[string]string{"removeCPULimit": "true"},
+			expectError: true,
+		},
+		{
+			name:        "already at max",
+			params:      map[string]string{"maxCPU": "500m"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remediation := &k8shealerv1alpha1.Remediation{
+				Spec: k8shealerv1alpha1.RemediationSpec{
+					Target: k8shealerv1alpha1.TargetResource{
+						Kind:      "Deployment",
+						Name:      "test-app",
+						Namespace: "default",
+						Container: "app",
+					},
+					Action: k8shealerv1alpha1.Action{
+						Type:   k8shealerv1alpha1.ActionTypeIncreaseCPU,
+						Params: tt.params,
+					},
+				},
+			}
+
+			input := tt.input
+			if input == "" {
+				input = inputYAML
+			}
+			patchedYAML, err := NewPatcher().PatchManifest(input, remediation)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("PatchManifest() error = %v, expectError %v", err, tt.expectError)
+			}
+			if tt.expectError {
+				return
+			}
+			for _, s := range tt.contains {
+				if !strings.Contains(patchedYAML, s) {
+					t.Errorf("Expected %q in:\n%s", s, patchedYAML)
+				}
+			}
+			for _, s := range tt.excludes {
+				if strings.Contains(patchedYAML, s) {
+					t.Errorf("Did not expect %q in:\n%s", s, patchedYAML)
+				}
+			}
+			if tt.params["removeCPULimit"] == "true" && strings.Count(patchedYAML, "cpu:") != 1 {
+				t.Errorf("Expected only the CPU request to remain, got:\n%s", patchedYAML)
+			}
+		})
+	}
+}
@@ -125,4 +125,37 @@ func (in *RemediationStatus) DeepCopyInto(out *RemediationStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CoalescedFingerprints != nil {
+		in, out := &in.CoalescedFingerprints, &out.CoalescedFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResolvedFingerprints != nil {
+		in, out := &in.ResolvedFingerprints, &out.ResolvedFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(RollbackStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+func (in *RollbackStatus) DeepCopyInto(out *RollbackStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make(map[string]string, len(*in))
+		for k, v := range *in {
+			(*out)[k] = v
+		}
+	}
+	if in.InitImages != nil {
+		in, out := &in.InitImages, &out.InitImages
+		*out = make(map[string]string, len(*in))
+		for k, v := range *in {
+			(*out)[k] = v
+		}
+	}
 }
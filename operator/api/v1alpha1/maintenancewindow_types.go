@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MaintenanceWindowType decides whether remediations are blocked inside or outside the window
+// +kubebuilder:validation:Enum=Freeze;AllowedOnly
+type MaintenanceWindowType string
+
+const (
+	// MaintenanceWindowTypeFreeze blocks Direct applies and PRs while the window is open
+	MaintenanceWindowTypeFreeze MaintenanceWindowType = "Freeze"
+	// MaintenanceWindowTypeAllowedOnly only allows Direct applies and PRs while the window is open
+	MaintenanceWindowTypeAllowedOnly MaintenanceWindowType = "AllowedOnly"
+)
+
+// TimeRange is a fixed period of time
+type TimeRange struct {
+	// Start of the range
+	Start metav1.Time `json:"start"`
+
+	// End of the range (exclusive)
+	End metav1.Time `json:"end"`
+}
+
+// MaintenanceWindowSpec defines when and where the window applies
+type MaintenanceWindowSpec struct {
+	// Type is Freeze (no changes while open) or AllowedOnly (changes only while open)
+	Type MaintenanceWindowType `json:"type"`
+
+	// Schedule is a cron expression (minute hour day-of-month month day-of-week)
+	// for when the window opens. Requires Duration.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Duration is how long the window stays open after each scheduled start, e.g. "2h"
+	// +optional
+	Duration string `json:"duration,omitempty"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in. Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// Ranges are fixed periods during which the window is open
+	// +optional
+	Ranges []TimeRange `json:"ranges,omitempty"`
+
+	// NamespaceSelector selects the namespaces of affected targets. Empty selects all namespaces.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Selector selects affected targets by their labels. Empty selects all workloads.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	// Active is true while the window is open
+	Active bool `json:"active"`
+
+	// NextTransitionTime is when the window next opens or closes
+	// +optional
+	NextTransitionTime *metav1.Time `json:"nextTransitionTime,omitempty"`
+
+	// Error describes why the spec could not be evaluated
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// MaintenanceWindow holds remediations during release freezes or outside planned maintenance
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=mw
+// +kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+// +kubebuilder:printcolumn:name="Active",type=boolean,JSONPath=`.status.active`
+// +kubebuilder:printcolumn:name="Next Transition",type=date,JSONPath=`.status.nextTransitionTime`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceWindowSpec   `json:"spec,omitempty"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+// MaintenanceWindowList contains a list of MaintenanceWindow
+// +kubebuilder:object:root=true
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
@@ -5,7 +5,7 @@ import (
 )
 
 // RemediationPhase represents the current phase of the remediation process
-// +kubebuilder:validation:Enum=Pending;Analyzing;PRCreated;Applying;Succeeded;Failed;Expired
+// +kubebuilder:validation:Enum=Pending;Analyzing;PRCreated;Applying;Succeeded;Failed;Expired;Cancelled;Throttled;OnHold
 type RemediationPhase string
 
 const (
@@ -16,15 +16,20 @@ const (
 	RemediationPhaseSucceeded RemediationPhase = "Succeeded"
 	RemediationPhaseFailed    RemediationPhase = "Failed"
 	RemediationPhaseExpired   RemediationPhase = "Expired"
+	RemediationPhaseCancelled RemediationPhase = "Cancelled"
+	RemediationPhaseThrottled RemediationPhase = "Throttled"
+	RemediationPhaseOnHold    RemediationPhase = "OnHold"
 )
 
 // ActionType represents the type of remediation action to take
-// +kubebuilder:validation:Enum=IncreaseMemory;ScaleUp;RollbackImage;CustomScript
+// +kubebuilder:validation:Enum=IncreaseMemory;IncreaseCPU;ScaleUp;RestartPods;RollbackImage;CustomScript
 type ActionType string
 
 const (
 	ActionTypeIncreaseMemory ActionType = "IncreaseMemory"
+	ActionTypeIncreaseCPU    ActionType = "IncreaseCPU"
 	ActionTypeScaleUp        ActionType = "ScaleUp"
+	ActionTypeRestartPods    ActionType = "RestartPods"
 	ActionTypeRollbackImage  ActionType = "RollbackImage"
 	ActionTypeCustomScript   ActionType = "CustomScript"
 )
@@ -69,7 +74,7 @@ type AlertInfo struct {
 	// Fingerprint is the Alertmanager fingerprint for deduplication
 	Fingerprint string `json:"fingerprint"`
 
-	// Source of the alert (e.g., "alertmanager")
+	// Source of the alert: alertmanager, grafana, generic or detector
 	Source string `json:"source"`
 
 	// Severity of the alert
@@ -79,12 +84,17 @@ type AlertInfo struct {
 	// Payload is the raw alert payload (JSON)
 	// +optional
 	Payload string `json:"payload,omitempty"`
+
+	// Route is the name of the routing rule that matched the alert
+	// +optional
+	Route string `json:"route,omitempty"`
 }
 
 // TargetResource identifies the Kubernetes resource to remediate
 type TargetResource struct {
-	// Kind of the resource (Deployment, StatefulSet, DaemonSet)
-	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet
+	// Kind of the resource (Deployment, StatefulSet, DaemonSet, Job, CronJob).
+	// Pod is resolved to the workload owning the pod before remediation.
+	// +kubebuilder:validation:Enum=Deployment;StatefulSet;DaemonSet;Job;CronJob;Pod
 	Kind string `json:"kind"`
 
 	// Name of the resource
@@ -201,6 +211,56 @@ type RemediationStatus struct {
 	// Conditions represent the latest available observations
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CoalescedFingerprints lists alerts for the same target and action that
+	// were folded into this remediation instead of creating their own
+	// +optional
+	CoalescedFingerprints []string `json:"coalescedFingerprints,omitempty"`
+
+	// ResolvedFingerprints lists the alerts contributing to this remediation
+	// that have resolved. The remediation is only treated as resolved once
+	// all of them have.
+	// +optional
+	ResolvedFingerprints []string `json:"resolvedFingerprints,omitempty"`
+
+	// SilenceID is the Alertmanager silence created after the remediation
+	// was applied
+	// +optional
+	SilenceID string `json:"silenceID,omitempty"`
+
+	// JobName is the Job running a CustomScript remediation
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// JobLogTail is the end of the CustomScript Job's logs
+	// +optional
+	JobLogTail string `json:"jobLogTail,omitempty"`
+
+	// Rollback is the revision a RollbackImage remediation restored
+	// +optional
+	Rollback *RollbackStatus `json:"rollback,omitempty"`
+}
+
+// RollbackStatus records the revision and images restored by a rollback
+type RollbackStatus struct {
+	// Revision is the workload revision that was restored; 0 when the images
+	// came from the workload's stable-images annotation
+	Revision int64 `json:"revision"`
+
+	// Source is the ReplicaSet, ControllerRevision or annotation the images
+	// were read from
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Images maps the names of the containers that were changed to their
+	// restored images
+	// +optional
+	Images map[string]string `json:"images,omitempty"`
+
+	// InitImages maps the names of the init containers that were changed to
+	// their restored images
+	// +optional
+	InitImages map[string]string `json:"initImages,omitempty"`
 }
 
 // Remediation is the Schema for the remediations API
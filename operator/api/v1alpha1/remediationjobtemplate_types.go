@@ -0,0 +1,41 @@
+package v1alpha1
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemediationJobTemplateSpec describes the Job a CustomScript remediation runs
+type RemediationJobTemplateSpec struct {
+	// ServiceAccountName is the ServiceAccount the Job's pods run as. It must
+	// be a dedicated account with only the permissions the script needs, not "default".
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// JobTemplate is the Job to create. Alert and target details are added to
+	// every container as HEAL8S_* environment variables.
+	JobTemplate batchv1.JobTemplateSpec `json:"jobTemplate"`
+}
+
+// RemediationJobTemplate is a runbook step that CustomScript remediations run as a Job
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rjt
+// +kubebuilder:printcolumn:name="Service Account",type=string,JSONPath=`.spec.serviceAccountName`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+type RemediationJobTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RemediationJobTemplateSpec `json:"spec,omitempty"`
+}
+
+// RemediationJobTemplateList contains a list of RemediationJobTemplate
+// +kubebuilder:object:root=true
+type RemediationJobTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemediationJobTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RemediationJobTemplate{}, &RemediationJobTemplateList{})
+}
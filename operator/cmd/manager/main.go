@@ -17,10 +17,15 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -28,15 +33,23 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/alertmanager"
+	"github.com/heal8s/heal8s/operator/internal/config"
 	"github.com/heal8s/heal8s/operator/internal/controller"
 	"github.com/heal8s/heal8s/operator/internal/dashboard"
+	"github.com/heal8s/heal8s/operator/internal/detectors"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
 	"github.com/heal8s/heal8s/operator/internal/webhooks"
 )
 
@@ -55,10 +68,62 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var webhookPort int
+	var configPath string
+	var configReloadInterval time.Duration
+	var webhookMaxBodyBytes int64
+	var dedupWindow time.Duration
+	var coalesceWindow time.Duration
+	var remediationNamespace string
+	var alertQueueSize int
+	var alertWorkers int
+	var webhookBearerTokenFile string
+	var webhookBasicAuthUsername string
+	var webhookBasicAuthPasswordFile string
+	var webhookTLSCertFile string
+	var webhookTLSKeyFile string
+	var webhookTLSClientCAFile string
+	var alertmanagerURL string
+	var silenceDuration time.Duration
+	var stableImageSoakTime time.Duration
+	var stableImageHistory int
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.IntVar(&webhookPort, "webhook-port", 8082, "The port the Alertmanager webhook endpoint binds to.")
+	flag.StringVar(&configPath, "config", "",
+		"Path to the operator config file (alert routing). If empty, built-in default routes are used.")
+	flag.DurationVar(&configReloadInterval, "config-reload-interval", 30*time.Second,
+		"How often the config file is checked for changes.")
+	flag.Int64Var(&webhookMaxBodyBytes, "webhook-max-body-bytes", webhooks.DefaultMaxBodyBytes,
+		"Maximum size of a webhook request body in bytes.")
+	flag.DurationVar(&dedupWindow, "dedup-window", webhooks.DefaultDedupWindow,
+		"How long an existing Remediation suppresses repeat notifications of the same alert.")
+	flag.DurationVar(&coalesceWindow, "coalesce-window", webhooks.DefaultCoalesceWindow,
+		"How long a Remediation absorbs further alerts for the same target and action. 0 disables coalescing.")
+	flag.StringVar(&remediationNamespace, "remediation-namespace", "",
+		"Namespace to create all Remediations in. If empty, each Remediation is created in its target's namespace.")
+	flag.IntVar(&alertQueueSize, "alert-queue-size", webhooks.DefaultQueueSize,
+		"Number of alerts that can wait for processing before the webhook responds with 503.")
+	flag.IntVar(&alertWorkers, "alert-workers", webhooks.DefaultWorkers, "Number of alerts processed concurrently.")
+	flag.StringVar(&webhookBearerTokenFile, "webhook-bearer-token-file", "",
+		"File containing the bearer token webhook requests must present.")
+	flag.StringVar(&webhookBasicAuthUsername, "webhook-basic-auth-username", "",
+		"Username webhook requests must present with HTTP basic auth.")
+	flag.StringVar(&webhookBasicAuthPasswordFile, "webhook-basic-auth-password-file", "",
+		"File containing the password webhook requests must present with HTTP basic auth.")
+	flag.StringVar(&webhookTLSCertFile, "webhook-tls-cert-file", "",
+		"TLS certificate for the webhook server. If set with --webhook-tls-key-file, the server uses HTTPS.")
+	flag.StringVar(&webhookTLSKeyFile, "webhook-tls-key-file", "", "TLS private key for the webhook server.")
+	flag.StringVar(&webhookTLSClientCAFile, "webhook-tls-client-ca-file", "",
+		"CA bundle used to verify client certificates. If set, clients must present a valid certificate (mTLS).")
+	flag.StringVar(&alertmanagerURL, "alertmanager-url", "",
+		"Alertmanager base URL. If set, the triggering alert is silenced after a direct remediation is applied.")
+	flag.DurationVar(&silenceDuration, "silence-duration", controller.DefaultSilenceDuration,
+		"How long the alert is silenced after a direct remediation while the change is verified.")
+	flag.DurationVar(&stableImageSoakTime, "stable-image-soak-time", controller.DefaultStableImageSoakTime,
+		"How long a completed rollout of an opted-in workload must stay healthy before its images are recorded as stable. 0 disables tracking.")
+	flag.IntVar(&stableImageHistory, "stable-image-history", remediate.DefaultStableImagesHistory,
+		"Number of stable image sets kept on each workload for rollbacks.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
@@ -71,8 +136,20 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
+	var cacheOptions cache.Options
+	if remediationNamespace != "" {
+		// Only the central namespace holds Remediations, so that is all the
+		// operator needs to watch (and be allowed to write)
+		cacheOptions.ByObject = map[client.Object]cache.ByObject{
+			&k8shealerv1alpha1.Remediation{}: {
+				Namespaces: map[string]cache.Config{remediationNamespace: {}},
+			},
+		}
+	}
+
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme: scheme,
+		Cache:  cacheOptions,
 		Metrics: metricsserver.Options{
 			BindAddress: metricsAddr,
 		},
@@ -85,11 +162,32 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err = (&controller.RemediationReconciler{
+	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create clientset")
+		os.Exit(1)
+	}
+
+	remediationReconciler := &controller.RemediationReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		SilenceDuration: silenceDuration,
+		APIReader:       mgr.GetAPIReader(),
+		PodLogs:         controller.NewPodLogs(clientset),
+	}
+	if alertmanagerURL != "" {
+		remediationReconciler.Silencer = alertmanager.NewClient(alertmanagerURL)
+		setupLog.Info("Silencing remediated alerts", "alertmanager", alertmanagerURL, "duration", silenceDuration)
+	}
+	if err = remediationReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Remediation")
+		os.Exit(1)
+	}
+	if err = (&controller.MaintenanceWindowReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Remediation")
+		setupLog.Error(err, "unable to create controller", "controller", "MaintenanceWindow")
 		os.Exit(1)
 	}
 
@@ -102,11 +200,105 @@ func main() {
 		os.Exit(1)
 	}
 
+	var routes webhooks.RouterConfigSource
+	var scope webhooks.ScopeSource
+	var rateLimits webhooks.RateLimitSource
+	var watcher *config.Watcher
+	if configPath != "" {
+		watcher, err = config.NewWatcher(configPath, configReloadInterval, ctrl.Log.WithName("config"))
+		if err != nil {
+			setupLog.Error(err, "unable to load operator config", "path", configPath)
+			os.Exit(1)
+		}
+		if err := mgr.Add(watcher); err != nil {
+			setupLog.Error(err, "unable to set up config watcher")
+			os.Exit(1)
+		}
+		routes = watcher
+		scope = watcher
+		rateLimits = watcher
+	}
+
+	// Stable images give RollbackImage something to return to when a
+	// workload has no usable rollout history
+	if stableImageSoakTime > 0 {
+		for _, kind := range controller.StableImageKinds {
+			if err := (&controller.StableImageReconciler{
+				Client:       mgr.GetClient(),
+				Scheme:       mgr.GetScheme(),
+				Kind:         kind,
+				Scope:        scope,
+				SoakTime:     stableImageSoakTime,
+				HistoryLimit: stableImageHistory,
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "StableImage", "kind", kind)
+				os.Exit(1)
+			}
+		}
+		setupLog.Info("Tracking stable images", "soakTime", stableImageSoakTime, "history", stableImageHistory)
+	}
+
+	webhookAuth, err := loadWebhookAuth(webhookBearerTokenFile, webhookBasicAuthUsername, webhookBasicAuthPasswordFile)
+	if err != nil {
+		setupLog.Error(err, "invalid webhook authentication settings")
+		os.Exit(1)
+	}
+	if !webhookAuth.Enabled() {
+		setupLog.Info("webhook authentication is disabled; anyone who can reach the webhook port can trigger remediations")
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
+	var tlsConfig *tls.Config
+	if webhookTLSCertFile != "" || webhookTLSKeyFile != "" {
+		tlsConfig, err = webhookTLSConfig(ctx, webhookTLSCertFile, webhookTLSKeyFile, webhookTLSClientCAFile)
+		if err != nil {
+			setupLog.Error(err, "unable to set up webhook TLS")
+			os.Exit(1)
+		}
+	} else if webhookTLSClientCAFile != "" {
+		setupLog.Error(nil, "--webhook-tls-client-ca-file requires --webhook-tls-cert-file and --webhook-tls-key-file")
+		os.Exit(1)
+	}
+
+	if coalesceWindow <= 0 {
+		coalesceWindow = -1
+	}
+	handler := webhooks.NewAlertmanagerHandler(mgr.GetClient(), mgr.GetScheme(), ctrl.Log.WithName("webhook"), webhooks.HandlerOptions{
+		Routes:               routes,
+		Scope:                scope,
+		RateLimits:           rateLimits,
+		RemediationNamespace: remediationNamespace,
+		MaxBodyBytes:         webhookMaxBodyBytes,
+		DedupWindow:          dedupWindow,
+		CoalesceWindow:       coalesceWindow,
+		QueueSize:            alertQueueSize,
+		Workers:              alertWorkers,
+	})
+	// The manager runs the alert workers and drains the queue on shutdown
+	if err := mgr.Add(handler); err != nil {
+		setupLog.Error(err, "unable to set up alert queue")
+		os.Exit(1)
+	}
+
+	// Pod detectors raise alerts without Alertmanager. Thresholds and per-detector
+	// switches are reloaded with the config; the informer is only started if a
+	// detector is enabled at startup.
+	if watcher != nil && watcher.DetectorConfig().AnyEnabled() {
+		detector := detectors.NewPodDetector(mgr.GetCache(), handler, watcher, ctrl.Log.WithName("detectors"))
+		if err := mgr.Add(detector); err != nil {
+			setupLog.Error(err, "unable to set up pod detectors")
+			os.Exit(1)
+		}
+	}
+
 	// Start HTTP server: webhook, dashboard UI, health
 	go func() {
-		handler := webhooks.NewAlertmanagerHandler(mgr.GetClient(), mgr.GetScheme(), setupLog)
 		mux := http.NewServeMux()
-		mux.HandleFunc("/webhooks/alertmanager", handler.HandleWebhook)
+		mux.HandleFunc("/webhooks/alertmanager", webhooks.RequireAuth(webhookAuth, handler.HandleWebhook))
+		mux.HandleFunc("/webhooks/grafana", webhooks.RequireAuth(webhookAuth, handler.HandleGrafanaWebhook))
+		mux.HandleFunc("/webhooks/generic", webhooks.RequireAuth(webhookAuth, handler.HandleGenericWebhook))
+		mux.HandleFunc("/webhooks/dry-run", webhooks.RequireAuth(webhookAuth, handler.HandleDryRun))
 		mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("ok"))
@@ -114,17 +306,108 @@ func main() {
 		// Dashboard (/, /dashboard, /api/events) — one catch-all so paths are handled inside dashboard
 		mux.HandleFunc("/", dashboard.ServeHTTP)
 
-		addr := fmt.Sprintf(":%d", webhookPort)
-		setupLog.Info("starting webhook and dashboard server", "address", addr, "dashboard", "http://localhost"+addr+"/")
-		if err := http.ListenAndServe(addr, mux); err != nil {
-			setupLog.Error(err, "problem running webhook server")
+		server := &http.Server{
+			Addr:              fmt.Sprintf(":%d", webhookPort),
+			Handler:           mux,
+			TLSConfig:         tlsConfig,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       30 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       2 * time.Minute,
+		}
+
+		setupLog.Info("starting webhook and dashboard server", "address", server.Addr, "tls", tlsConfig != nil,
+			"mtls", webhookTLSClientCAFile != "", "auth", webhookAuth.Enabled())
+		var serveErr error
+		if tlsConfig != nil {
+			// Certificates come from TLSConfig.GetCertificate
+			serveErr = server.ListenAndServeTLS("", "")
+		} else {
+			serveErr = server.ListenAndServe()
+		}
+		if serveErr != nil {
+			setupLog.Error(serveErr, "problem running webhook server")
 			os.Exit(1)
 		}
 	}()
 
 	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgr.Start(ctx); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
 }
+
+// loadWebhookAuth builds the webhook authentication config from secret files
+func loadWebhookAuth(bearerTokenFile, username, passwordFile string) (webhooks.AuthConfig, error) {
+	auth := webhooks.AuthConfig{Username: username}
+
+	if bearerTokenFile != "" {
+		token, err := readSecretFile(bearerTokenFile)
+		if err != nil {
+			return auth, err
+		}
+		auth.BearerToken = token
+	}
+
+	if passwordFile != "" {
+		password, err := readSecretFile(passwordFile)
+		if err != nil {
+			return auth, err
+		}
+		auth.Password = password
+	}
+
+	return auth, auth.Validate()
+}
+
+func readSecretFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	value := strings.TrimSpace(string(data))
+	if value == "" {
+		return "", fmt.Errorf("%s is empty", path)
+	}
+	return value, nil
+}
+
+// webhookTLSConfig serves the certificate at certFile/keyFile, reloading it
+// when the files change, and requires client certificates signed by
+// clientCAFile if one is given.
+func webhookTLSConfig(ctx context.Context, certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, fmt.Errorf("both --webhook-tls-cert-file and --webhook-tls-key-file are required")
+	}
+
+	watcher, err := certwatcher.New(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load webhook certificate: %w", err)
+	}
+	go func() {
+		if err := watcher.Start(ctx); err != nil {
+			setupLog.Error(err, "webhook certificate watcher stopped")
+		}
+	}()
+
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: watcher.GetCertificate,
+	}
+
+	if clientCAFile != "" {
+		caPEM, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in client CA file %s", clientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package alertmanager is a minimal client for the Alertmanager v2 silences API
+package alertmanager
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultCreatedBy is recorded as the author of silences
+const DefaultCreatedBy = "heal8s"
+
+// Client talks to a single Alertmanager
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	createdBy  string
+	now        func() time.Time
+}
+
+// NewClient returns a Client for the Alertmanager at baseURL, e.g.
+// http://alertmanager.monitoring:9093
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		createdBy:  DefaultCreatedBy,
+		now:        time.Now,
+	}
+}
+
+// Matcher matches one alert label
+type Matcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+// Silence is the body of a silence create request
+type Silence struct {
+	Matchers  []Matcher `json:"matchers"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy string    `json:"createdBy"`
+	Comment   string    `json:"comment"`
+}
+
+// EqualMatchers returns exact-match matchers for labels, sorted by name.
+// Labels with empty values are left out since Alertmanager treats them as
+// "label absent".
+func EqualMatchers(labels map[string]string) []Matcher {
+	matchers := make([]Matcher, 0, len(labels))
+	for name, value := range labels {
+		if value == "" {
+			continue
+		}
+		matchers = append(matchers, Matcher{Name: name, Value: value, IsEqual: true})
+	}
+	sort.Slice(matchers, func(i, j int) bool { return matchers[i].Name < matchers[j].Name })
+	return matchers
+}
+
+// CreateSilence silences alerts carrying exactly labels for duration and
+// returns the silence ID
+func (c *Client) CreateSilence(ctx context.Context, labels map[string]string, duration time.Duration, comment string) (string, error) {
+	matchers := EqualMatchers(labels)
+	if len(matchers) == 0 {
+		return "", fmt.Errorf("no labels to match")
+	}
+	now := c.now()
+	body, err := json.Marshal(Silence{
+		Matchers:  matchers,
+		StartsAt:  now,
+		EndsAt:    now.Add(duration),
+		CreatedBy: c.createdBy,
+		Comment:   comment,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode silence: %w", err)
+	}
+
+	resp, err := c.do(ctx, http.MethodPost, "/api/v2/silences", body)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", unexpectedStatus(resp)
+	}
+
+	var created struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", fmt.Errorf("failed to decode silence response: %w", err)
+	}
+	if created.SilenceID == "" {
+		return "", fmt.Errorf("alertmanager returned no silence ID")
+	}
+	return created.SilenceID, nil
+}
+
+// ExpireSilence ends the silence with id now. A silence that no longer
+// exists is not an error.
+func (c *Client) ExpireSilence(ctx context.Context, id string) error {
+	resp, err := c.do(ctx, http.MethodDelete, "/api/v2/silence/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return unexpectedStatus(resp)
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build alertmanager request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call alertmanager: %w", err)
+	}
+	return resp, nil
+}
+
+func unexpectedStatus(resp *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("alertmanager returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
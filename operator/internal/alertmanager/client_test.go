@@ -0,0 +1,114 @@
+package alertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_CreateSilence(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	var got Silence
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v2/silences" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"silenceID":"abc-123"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL + "/")
+	client.now = func() time.Time { return now }
+
+	id, err := client.CreateSilence(context.Background(), map[string]string{
+		"alertname": "KubePodOOMKilled",
+		"namespace": "prod",
+		"instance":  "",
+	}, 10*time.Minute, "remediated")
+	if err != nil {
+		t.Fatalf("CreateSilence() error = %v", err)
+	}
+	if id != "abc-123" {
+		t.Errorf("id = %q, want abc-123", id)
+	}
+
+	want := []Matcher{
+		{Name: "alertname", Value: "KubePodOOMKilled", IsEqual: true},
+		{Name: "namespace", Value: "prod", IsEqual: true},
+	}
+	if !reflect.DeepEqual(got.Matchers, want) {
+		t.Errorf("matchers = %+v, want %+v", got.Matchers, want)
+	}
+	if !got.StartsAt.Equal(now) || !got.EndsAt.Equal(now.Add(10*time.Minute)) {
+		t.Errorf("silence runs %v to %v", got.StartsAt, got.EndsAt)
+	}
+	if got.CreatedBy != DefaultCreatedBy || got.Comment != "remediated" {
+		t.Errorf("createdBy = %q, comment = %q", got.CreatedBy, got.Comment)
+	}
+}
+
+func TestClient_CreateSilenceErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		status int
+		body   string
+	}{
+		{name: "no labels", labels: map[string]string{"instance": ""}, status: http.StatusOK, body: `{"silenceID":"x"}`},
+		{name: "rejected", labels: map[string]string{"alertname": "A"}, status: http.StatusBadRequest, body: `"invalid"`},
+		{name: "missing id", labels: map[string]string{"alertname": "A"}, status: http.StatusOK, body: `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			if _, err := NewClient(server.URL).CreateSilence(context.Background(), tt.labels, time.Minute, ""); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestClient_ExpireSilence(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		expectError bool
+	}{
+		{name: "expired", status: http.StatusOK},
+		{name: "already gone", status: http.StatusNotFound},
+		{name: "server error", status: http.StatusInternalServerError, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, path string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				method, path = r.Method, r.URL.Path
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewClient(server.URL).ExpireSilence(context.Background(), "abc-123")
+			if (err != nil) != tt.expectError {
+				t.Fatalf("ExpireSilence() error = %v, expectError %v", err, tt.expectError)
+			}
+			if method != http.MethodDelete || path != "/api/v2/silence/abc-123" {
+				t.Errorf("request = %s %s", method, path)
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/heal8s/heal8s/operator/internal/detectors"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// Config is the operator configuration file (config.yaml in the heal8s-config ConfigMap)
+type Config struct {
+	AlertRouting AlertRouting `yaml:"alertRouting"`
+	Detectors    Detectors    `yaml:"detectors"`
+	Namespaces   Namespaces   `yaml:"namespaces"`
+	Limits       RateLimits   `yaml:"rateLimits"`
+}
+
+// AlertRouting is the ordered list of routing rules.
+//
+// The legacy form, a mapping from alert name to {action, params}, is still
+// accepted and becomes one rule per alert name matching alertname="<name>",
+// in document order.
+type AlertRouting []RouteEntry
+
+// RouteEntry is a single routing rule
+type RouteEntry struct {
+	Name string `yaml:"name"`
+	// Matchers in Alertmanager syntax, e.g. namespace=~"batch-.*"
+	Matchers []string          `yaml:"matchers"`
+	Action   string            `yaml:"action"`
+	Params   map[string]string `yaml:"params"`
+	Continue bool              `yaml:"continue"`
+}
+
+// Detectors configures the in-cluster pod detectors. Unset thresholds keep
+// their defaults.
+type Detectors struct {
+	OOMKilled        OOMKilledDetector        `yaml:"oomKilled"`
+	CrashLoopBackOff CrashLoopBackOffDetector `yaml:"crashLoopBackOff"`
+	ImagePullBackOff ImagePullBackOffDetector `yaml:"imagePullBackOff"`
+}
+
+// OOMKilledDetector fires after threshold OOM kills within window
+type OOMKilledDetector struct {
+	Enabled   bool          `yaml:"enabled"`
+	Threshold int           `yaml:"threshold"`
+	Window    time.Duration `yaml:"window"`
+}
+
+// CrashLoopBackOffDetector fires on CrashLoopBackOff after minRestarts restarts
+type CrashLoopBackOffDetector struct {
+	Enabled     bool  `yaml:"enabled"`
+	MinRestarts int32 `yaml:"minRestarts"`
+}
+
+// ImagePullBackOffDetector fires when an image could not be pulled for the given duration
+type ImagePullBackOffDetector struct {
+	Enabled bool          `yaml:"enabled"`
+	For     time.Duration `yaml:"for"`
+}
+
+// Namespaces limits where remediation may happen. Namespaces and workloads
+// can further opt in or out with heal8s.io annotations.
+type Namespaces struct {
+	// Include lists namespace glob patterns to remediate; empty means all
+	Include []string `yaml:"include"`
+	// Exclude lists namespace glob patterns never to remediate
+	Exclude []string `yaml:"exclude"`
+	// RequireOptIn only remediates where heal8s.io/enabled=true is set
+	RequireOptIn bool `yaml:"requireOptIn"`
+}
+
+// RateLimits bounds how often workloads are changed. Zero values disable a limit.
+type RateLimits struct {
+	// Cooldown is how long after an applied remediation the same target and
+	// action are left alone
+	Cooldown time.Duration `yaml:"cooldown"`
+	// PerNamespace limits remediations per target namespace
+	PerNamespace Budget `yaml:"perNamespace"`
+	// Global limits remediations across the cluster
+	Global Budget `yaml:"global"`
+	// OnLimit is Throttle (create a Throttled Remediation) or Skip
+	OnLimit string `yaml:"onLimit"`
+}
+
+// Budget allows max remediations per window
+type Budget struct {
+	Max    int           `yaml:"max"`
+	Window time.Duration `yaml:"window"`
+}
+
+// legacyRouteEntry is a value in the legacy alertname-keyed mapping
+type legacyRouteEntry struct {
+	Action string            `yaml:"action"`
+	Params map[string]string `yaml:"params"`
+}
+
+// UnmarshalYAML accepts either a sequence of rules or the legacy mapping
+func (r *AlertRouting) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var entries []RouteEntry
+		if err := decodeStrict(node, &entries); err != nil {
+			return err
+		}
+		*r = entries
+		return nil
+	case yaml.MappingNode:
+		entries := make([]RouteEntry, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			alertName := node.Content[i].Value
+			var legacy legacyRouteEntry
+			if err := decodeStrict(node.Content[i+1], &legacy); err != nil {
+				return fmt.Errorf("alertRouting.%s: %w", alertName, err)
+			}
+			entries = append(entries, RouteEntry{
+				Name:     alertName,
+				Matchers: []string{fmt.Sprintf("alertname=%q", alertName)},
+				Action:   legacy.Action,
+				Params:   legacy.Params,
+			})
+		}
+		*r = entries
+		return nil
+	default:
+		return fmt.Errorf("line %d: alertRouting must be a list of rules", node.Line)
+	}
+}
+
+// decodeStrict decodes node into out, rejecting unknown fields. Node.Decode
+// does not inherit the outer decoder's KnownFields setting.
+func decodeStrict(node *yaml.Node, out interface{}) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	return decoder.Decode(out)
+}
+
+// LoadConfig reads and parses the configuration file at path
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse parses configuration YAML. Unknown fields are rejected so that typos
+// surface as errors instead of silently falling back to defaults.
+func Parse(data []byte) (*Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	return &config, nil
+}
+
+// RouterConfig converts the alertRouting section into a validated
+// remediate.RouterConfig. If no rules are configured the defaults are used.
+func (c *Config) RouterConfig() (remediate.RouterConfig, error) {
+	if len(c.AlertRouting) == 0 {
+		return remediate.DefaultRouterConfig(), nil
+	}
+
+	routerConfig := remediate.RouterConfig{
+		Rules: make([]remediate.RouteRule, 0, len(c.AlertRouting)),
+	}
+
+	for i, entry := range c.AlertRouting {
+		matchers := make([]*remediate.Matcher, 0, len(entry.Matchers))
+		for _, s := range entry.Matchers {
+			m, err := remediate.ParseMatcher(s)
+			if err != nil {
+				return remediate.RouterConfig{}, fmt.Errorf("invalid alertRouting: rule %d (%s): %w", i, entry.Name, err)
+			}
+			matchers = append(matchers, m)
+		}
+
+		params := make(map[string]string, len(entry.Params))
+		for k, v := range entry.Params {
+			params[k] = v
+		}
+
+		routerConfig.Rules = append(routerConfig.Rules, remediate.RouteRule{
+			Name:       entry.Name,
+			Matchers:   matchers,
+			ActionType: remediate.ActionType(entry.Action),
+			Params:     params,
+			Continue:   entry.Continue,
+		})
+	}
+
+	if err := routerConfig.Validate(); err != nil {
+		return remediate.RouterConfig{}, fmt.Errorf("invalid alertRouting: %w", err)
+	}
+
+	return routerConfig, nil
+}
+
+// DetectorConfig converts the detectors section into a detectors.Config,
+// filling in defaults for unset thresholds
+func (c *Config) DetectorConfig() (detectors.Config, error) {
+	config := detectors.DefaultConfig()
+	d := c.Detectors
+
+	config.OOMKilled.Enabled = d.OOMKilled.Enabled
+	if d.OOMKilled.Threshold < 0 {
+		return detectors.Config{}, fmt.Errorf("invalid detectors: oomKilled.threshold must be positive")
+	}
+	if d.OOMKilled.Threshold > 0 {
+		config.OOMKilled.Threshold = d.OOMKilled.Threshold
+	}
+	if d.OOMKilled.Window < 0 {
+		return detectors.Config{}, fmt.Errorf("invalid detectors: oomKilled.window must be positive")
+	}
+	if d.OOMKilled.Window > 0 {
+		config.OOMKilled.Window = d.OOMKilled.Window
+	}
+
+	config.CrashLoopBackOff.Enabled = d.CrashLoopBackOff.Enabled
+	if d.CrashLoopBackOff.MinRestarts < 0 {
+		return detectors.Config{}, fmt.Errorf("invalid detectors: crashLoopBackOff.minRestarts must be positive")
+	}
+	if d.CrashLoopBackOff.MinRestarts > 0 {
+		config.CrashLoopBackOff.MinRestarts = d.CrashLoopBackOff.MinRestarts
+	}
+
+	config.ImagePullBackOff.Enabled = d.ImagePullBackOff.Enabled
+	if d.ImagePullBackOff.For < 0 {
+		return detectors.Config{}, fmt.Errorf("invalid detectors: imagePullBackOff.for must be positive")
+	}
+	if d.ImagePullBackOff.For > 0 {
+		config.ImagePullBackOff.For = d.ImagePullBackOff.For
+	}
+
+	return config, nil
+}
+
+// Scope converts the namespaces section into a validated remediate.Scope
+func (c *Config) Scope() (remediate.Scope, error) {
+	scope := remediate.Scope{
+		IncludeNamespaces: c.Namespaces.Include,
+		ExcludeNamespaces: c.Namespaces.Exclude,
+		RequireOptIn:      c.Namespaces.RequireOptIn,
+	}
+	if err := scope.Validate(); err != nil {
+		return remediate.Scope{}, fmt.Errorf("invalid namespaces: %w", err)
+	}
+	return scope, nil
+}
+
+// RateLimits converts the rateLimits section into validated remediate.RateLimits
+func (c *Config) RateLimits() (remediate.RateLimits, error) {
+	r := c.Limits
+	limits := remediate.RateLimits{
+		Cooldown:     r.Cooldown,
+		PerNamespace: remediate.Budget{Max: r.PerNamespace.Max, Window: r.PerNamespace.Window},
+		Global:       remediate.Budget{Max: r.Global.Max, Window: r.Global.Window},
+		OnLimit:      remediate.OnLimit(r.OnLimit),
+	}
+	if limits.OnLimit == "" {
+		limits.OnLimit = remediate.OnLimitThrottle
+	}
+	if err := limits.Validate(); err != nil {
+		return remediate.RateLimits{}, fmt.Errorf("invalid rateLimits: %w", err)
+	}
+	return limits, nil
+}
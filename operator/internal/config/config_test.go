@@ -0,0 +1,387 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const validConfig = `alertRouting:
+  - name: oom-batch
+    matchers:
+      - alertname="KubePodOOMKilled"
+      - namespace=~"batch-.*"
+    action: IncreaseMemory
+    params:
+      maxMemory: "8Gi"
+  - name: oom-default
+    matchers:
+      - alertname="KubePodOOMKilled"
+    action: IncreaseMemory
+    params:
+      memoryIncreasePercent: 50
+      maxMemory: "4Gi"
+`
+
+const legacyConfig = `alertRouting:
+  KubePodOOMKilled:
+    action: IncreaseMemory
+    params:
+      memoryIncreasePercent: 50
+      maxMemory: "4Gi"
+  KubeHpaMaxedOut:
+    action: ScaleUp
+    params:
+      scaleUpPercent: "100"
+      maxReplicas: "20"
+`
+
+func TestParse_RouterConfig(t *testing.T) {
+	config, err := Parse([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	routerConfig, err := config.RouterConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(routerConfig.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(routerConfig.Rules))
+	}
+
+	if routerConfig.Rules[0].Name != "oom-batch" || routerConfig.Rules[1].Name != "oom-default" {
+		t.Errorf("expected rules in file order, got %s, %s", routerConfig.Rules[0].Name, routerConfig.Rules[1].Name)
+	}
+
+	rule := routerConfig.Rules[1]
+	if rule.ActionType != remediate.ActionTypeIncreaseMemory {
+		t.Errorf("expected action IncreaseMemory, got %s", rule.ActionType)
+	}
+	// Unquoted integers in the YAML are accepted as string params
+	if rule.Params["memoryIncreasePercent"] != "50" {
+		t.Errorf("expected memoryIncreasePercent 50, got %q", rule.Params["memoryIncreasePercent"])
+	}
+
+	batch := map[string]string{"alertname": "KubePodOOMKilled", "namespace": "batch-etl"}
+	if !routerConfig.Rules[0].Matches(batch) {
+		t.Error("expected oom-batch to match batch namespace")
+	}
+}
+
+func TestParse_LegacyMapping(t *testing.T) {
+	config, err := Parse([]byte(legacyConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	routerConfig, err := config.RouterConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(routerConfig.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(routerConfig.Rules))
+	}
+
+	rule := routerConfig.Rules[0]
+	if rule.Name != "KubePodOOMKilled" {
+		t.Errorf("expected rule named after alert, got %s", rule.Name)
+	}
+	if !rule.Matches(map[string]string{"alertname": "KubePodOOMKilled"}) {
+		t.Error("expected legacy rule to match its alertname")
+	}
+	if rule.Matches(map[string]string{"alertname": "KubeHpaMaxedOut"}) {
+		t.Error("expected legacy rule not to match other alertnames")
+	}
+	if rule.Params["memoryIncreasePercent"] != "50" {
+		t.Errorf("expected memoryIncreasePercent 50, got %q", rule.Params["memoryIncreasePercent"])
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+	}{
+		{
+			name:   "malformed YAML",
+			config: "alertRouting: [",
+		},
+		{
+			name:   "unknown top-level field",
+			config: "alertRoutes: {}",
+		},
+		{
+			name: "unknown action",
+			config: `alertRouting:
+  KubePodOOMKilled:
+    action: IncreaseMemroy
+`,
+		},
+		{
+			name: "unknown rule field",
+			config: `alertRouting:
+  - name: oom
+    matcher: alertname="KubePodOOMKilled"
+    action: IncreaseMemory
+`,
+		},
+		{
+			name: "invalid matcher regex",
+			config: `alertRouting:
+  - name: oom
+    matchers: ['namespace=~"("']
+    action: IncreaseMemory
+`,
+		},
+		{
+			name: "duplicate rule names",
+			config: `alertRouting:
+  - name: oom
+    action: IncreaseMemory
+  - name: oom
+    action: ScaleUp
+`,
+		},
+		{
+			name: "missing rule name",
+			config: `alertRouting:
+  - action: IncreaseMemory
+`,
+		},
+		{
+			name: "missing action",
+			config: `alertRouting:
+  KubePodOOMKilled:
+    params:
+      maxMemory: 2Gi
+`,
+		},
+		{
+			name: "invalid quantity",
+			config: `alertRouting:
+  KubePodOOMKilled:
+    action: IncreaseMemory
+    params:
+      maxMemory: two-gigs
+`,
+		},
+		{
+			name: "invalid param template",
+			config: `alertRouting:
+  KubePodOOMKilled:
+    action: IncreaseMemory
+    params:
+      maxMemory: '{{ .Annotations.max_memory'
+`,
+		},
+		{
+			name: "non-numeric percent",
+			config: `alertRouting:
+  KubeHpaMaxedOut:
+    action: ScaleUp
+    params:
+      scaleUpPercent: lots
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte(tt.config))
+			if err == nil {
+				_, err = config.RouterConfig()
+			}
+			if err == nil {
+				t.Error("expected error but got none")
+			}
+		})
+	}
+}
+
+func TestParse_EmptyUsesDefaults(t *testing.T) {
+	config, err := Parse([]byte(""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	routerConfig, err := config.RouterConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(routerConfig.Rules) != len(remediate.DefaultRouterConfig().Rules) {
+		t.Errorf("expected default rules, got %d rules", len(routerConfig.Rules))
+	}
+}
+
+func TestWatcher_KeepsLastGoodConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(validConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := NewWatcher(path, time.Minute, logr.Discard())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Invalid update is rejected and the previous routes stay active
+	if err := os.WriteFile(path, []byte("alertRouting:\n  Foo:\n    action: Nope\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.reload(); err == nil {
+		t.Error("expected reload error for invalid config")
+	}
+	if rules := w.RouterConfig().Rules; len(rules) != 2 || rules[0].Name != "oom-batch" {
+		t.Error("expected previous config to remain active")
+	}
+
+	// Valid update is picked up
+	updated := `alertRouting:
+  ContainerOOMKilled:
+    action: IncreaseMemory
+`
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.reload(); err != nil {
+		t.Fatalf("unexpected reload error: %v", err)
+	}
+	rules := w.RouterConfig().Rules
+	if len(rules) != 1 || rules[0].Name != "ContainerOOMKilled" {
+		t.Errorf("expected only ContainerOOMKilled rule, got %v", rules)
+	}
+}
+
+func TestNewWatcher_InvalidInitialConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("alertRouting: ["), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewWatcher(path, time.Minute, logr.Discard()); err == nil {
+		t.Error("expected error for invalid initial config")
+	}
+}
+
+func TestParse_Detectors(t *testing.T) {
+	config, err := Parse([]byte(`detectors:
+  oomKilled:
+    enabled: true
+    threshold: 3
+    window: 15m
+  crashLoopBackOff:
+    enabled: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	detectorConfig, err := config.DetectorConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !detectorConfig.OOMKilled.Enabled || detectorConfig.OOMKilled.Threshold != 3 || detectorConfig.OOMKilled.Window != 15*time.Minute {
+		t.Errorf("unexpected oomKilled config: %+v", detectorConfig.OOMKilled)
+	}
+	// Unset thresholds keep their defaults
+	if !detectorConfig.CrashLoopBackOff.Enabled || detectorConfig.CrashLoopBackOff.MinRestarts != 3 {
+		t.Errorf("unexpected crashLoopBackOff config: %+v", detectorConfig.CrashLoopBackOff)
+	}
+	if detectorConfig.ImagePullBackOff.Enabled {
+		t.Error("expected imagePullBackOff to stay disabled")
+	}
+
+	config, err = Parse([]byte("detectors:\n  oomKilled:\n    threshold: -1\n"))
+	if err == nil {
+		_, err = config.DetectorConfig()
+	}
+	if err == nil {
+		t.Error("expected error for negative threshold")
+	}
+}
+
+func TestParse_Namespaces(t *testing.T) {
+	config, err := Parse([]byte(`namespaces:
+  include: ["prod", "team-*"]
+  exclude: ["kube-system"]
+  requireOptIn: true
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	scope, err := config.Scope()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !scope.RequireOptIn || !scope.AllowsNamespace("team-a") || scope.AllowsNamespace("dev") {
+		t.Errorf("unexpected scope: %+v", scope)
+	}
+
+	config, err = Parse([]byte("namespaces:\n  exclude: [\"[\"]\n"))
+	if err == nil {
+		_, err = config.Scope()
+	}
+	if err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestParse_RateLimits(t *testing.T) {
+	config, err := Parse([]byte(`rateLimits:
+  cooldown: 1h
+  perNamespace:
+    max: 3
+    window: 1h
+  global:
+    max: 10
+    window: 30m
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	limits, err := config.RateLimits()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := remediate.RateLimits{
+		Cooldown:     time.Hour,
+		PerNamespace: remediate.Budget{Max: 3, Window: time.Hour},
+		Global:       remediate.Budget{Max: 10, Window: 30 * time.Minute},
+		OnLimit:      remediate.OnLimitThrottle,
+	}
+	if limits != want {
+		t.Errorf("got %+v, want %+v", limits, want)
+	}
+
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{name: "budget without window", yaml: "rateLimits:\n  global:\n    max: 5\n"},
+		{name: "negative cooldown", yaml: "rateLimits:\n  cooldown: -1h\n"},
+		{name: "unknown onLimit", yaml: "rateLimits:\n  onLimit: Drop\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Parse([]byte(tt.yaml))
+			if err == nil {
+				_, err = config.RateLimits()
+			}
+			if err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
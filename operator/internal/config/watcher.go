@@ -0,0 +1,188 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/heal8s/heal8s/operator/internal/detectors"
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// Watcher holds the active routing, detector, scope and rate limit configuration and reloads it when the
+// config file changes. ConfigMap volumes are updated by the kubelet through a
+// symlink swap, so the file is polled rather than watched with inotify.
+type Watcher struct {
+	path     string
+	interval time.Duration
+	logger   logr.Logger
+
+	mu             sync.RWMutex
+	routerConfig   remediate.RouterConfig
+	detectorConfig detectors.Config
+	scope          remediate.Scope
+	rateLimits     remediate.RateLimits
+	// lastData is the last file content seen, valid or not, so an invalid
+	// file is reported once rather than on every poll.
+	lastData []byte
+}
+
+// NewWatcher loads the config file at path and returns a watcher serving it.
+// An error is returned if the initial load fails.
+func NewWatcher(path string, interval time.Duration, logger logr.Logger) (*Watcher, error) {
+	w := &Watcher{
+		path:     path,
+		interval: interval,
+		logger:   logger,
+	}
+
+	if err := w.reload(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// RouterConfig returns the last successfully loaded routing configuration
+func (w *Watcher) RouterConfig() remediate.RouterConfig {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.routerConfig
+}
+
+// DetectorConfig returns the last successfully loaded detector configuration
+func (w *Watcher) DetectorConfig() detectors.Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.detectorConfig
+}
+
+// Scope returns the last successfully loaded namespace scope
+func (w *Watcher) Scope() remediate.Scope {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.scope
+}
+
+// RateLimits returns the last successfully loaded rate limits
+func (w *Watcher) RateLimits() remediate.RateLimits {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.rateLimits
+}
+
+// Start polls the config file until ctx is cancelled. It implements manager.Runnable.
+func (w *Watcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	w.logger.Info("watching operator config", "path", w.path, "interval", w.interval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.reload(); err != nil {
+				// Keep serving the last good config
+				w.logger.Error(err, "Failed to reload operator config, keeping previous config", "path", w.path)
+			}
+		}
+	}
+}
+
+// NeedLeaderElection returns false: every replica serves the webhook and
+// needs the current routes.
+func (w *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+func (w *Watcher) reload() error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		metrics.ConfigReloads.WithLabelValues("error").Inc()
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	w.mu.RLock()
+	unchanged := w.lastData != nil && bytes.Equal(data, w.lastData)
+	w.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	parsed, err := parseConfig(data)
+
+	w.mu.Lock()
+	w.lastData = data
+	if err == nil {
+		w.routerConfig = parsed.routerConfig
+		w.detectorConfig = parsed.detectorConfig
+		w.scope = parsed.scope
+		w.rateLimits = parsed.rateLimits
+	}
+	w.mu.Unlock()
+
+	if err != nil {
+		metrics.ConfigReloads.WithLabelValues("error").Inc()
+		return err
+	}
+
+	metrics.ConfigReloads.WithLabelValues("success").Inc()
+	w.logger.Info("Loaded operator config", "path", w.path, "rules", len(parsed.routerConfig.Rules))
+	return nil
+}
+
+// parsedConfig is a fully validated config file
+type parsedConfig struct {
+	routerConfig   remediate.RouterConfig
+	detectorConfig detectors.Config
+	scope          remediate.Scope
+	rateLimits     remediate.RateLimits
+}
+
+func parseConfig(data []byte) (parsedConfig, error) {
+	config, err := Parse(data)
+	if err != nil {
+		return parsedConfig{}, err
+	}
+	routerConfig, err := config.RouterConfig()
+	if err != nil {
+		return parsedConfig{}, err
+	}
+	detectorConfig, err := config.DetectorConfig()
+	if err != nil {
+		return parsedConfig{}, err
+	}
+	scope, err := config.Scope()
+	if err != nil {
+		return parsedConfig{}, err
+	}
+	rateLimits, err := config.RateLimits()
+	if err != nil {
+		return parsedConfig{}, err
+	}
+	return parsedConfig{routerConfig: routerConfig, detectorConfig: detectorConfig, scope: scope, rateLimits: rateLimits}, nil
+}
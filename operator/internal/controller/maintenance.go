@@ -0,0 +1,221 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/maintenance"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// ConditionMaintenanceWindow is True while a Remediation is held by a MaintenanceWindow
+const ConditionMaintenanceWindow = "MaintenanceWindow"
+
+// holdRecheckInterval bounds how long a held Remediation waits before the
+// windows are evaluated again
+const holdRecheckInterval = 5 * time.Minute
+
+// checkMaintenanceWindows returns the hold the MaintenanceWindows put on
+// remediation's target, or nil if it may proceed, and when a window next
+// opens or closes (zero if none will)
+func (r *RemediationReconciler) checkMaintenanceWindows(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (*maintenance.Hold, time.Time, error) {
+	windows := &k8shealerv1alpha1.MaintenanceWindowList{}
+	if err := r.List(ctx, windows); err != nil {
+		return nil, time.Time{}, fmt.Errorf("failed to list maintenance windows: %w", err)
+	}
+	if len(windows.Items) == 0 {
+		return nil, time.Time{}, nil
+	}
+
+	target := maintenance.Target{Namespace: remediation.Spec.Target.Namespace}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: target.Namespace}, namespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, time.Time{}, fmt.Errorf("failed to get namespace %s: %w", target.Namespace, err)
+		}
+	}
+	target.NamespaceLabels = namespace.Labels
+
+	if workload, err := remediate.NewTargetObject(remediation.Spec.Target.Kind); err == nil {
+		key := client.ObjectKey{Namespace: target.Namespace, Name: remediation.Spec.Target.Name}
+		if err := r.Get(ctx, key, workload); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, time.Time{}, fmt.Errorf("failed to get target: %w", err)
+			}
+		}
+		target.Labels = workload.GetLabels()
+	}
+
+	now := time.Now()
+	return maintenance.Decide(windows.Items, target, now), maintenance.NextChange(windows.Items, target, now), nil
+}
+
+// holdRemediation puts remediation on hold until hold ends
+func (r *RemediationReconciler) holdRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation, hold *maintenance.Hold) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if remediation.Status.Phase != k8shealerv1alpha1.RemediationPhaseOnHold || remediation.Status.Reason != hold.Message {
+		logger.Info("Holding remediation for maintenance window", "windows", hold.Windows, "reason", hold.Reason)
+
+		remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseOnHold
+		remediation.Status.Reason = hold.Message
+		now := metav1.Now()
+		remediation.Status.LastUpdateTime = &now
+		meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+			Type:               ConditionMaintenanceWindow,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: remediation.Generation,
+			LastTransitionTime: now,
+			Reason:             hold.Reason,
+			Message:            hold.Message,
+		})
+
+		if err := r.Status().Update(ctx, remediation); err != nil {
+			logger.Error(err, "Failed to update status to OnHold")
+			return ctrl.Result{}, err
+		}
+	}
+
+	requeue := holdRecheckInterval
+	if !hold.Until.IsZero() {
+		if untilEnd := time.Until(hold.Until); untilEnd < requeue {
+			requeue = max(untilEnd, time.Second)
+		}
+	}
+	if remaining, ok := ttlRemaining(remediation); ok && remaining < requeue {
+		requeue = max(remaining, time.Second)
+	}
+	return ctrl.Result{RequeueAfter: requeue}, nil
+}
+
+// handleOnHoldRemediation resumes remediation once no window holds it. The
+// TTL keeps running while it waits.
+func (r *RemediationReconciler) handleOnHoldRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if remaining, ok := ttlRemaining(remediation); ok && remaining <= 0 {
+		return r.updateStatusToExpired(ctx, remediation, "Remediation TTL exceeded while on hold for a maintenance window")
+	}
+
+	hold, _, err := r.checkMaintenanceWindows(ctx, remediation)
+	if err != nil {
+		logger.Error(err, "Failed to check maintenance windows")
+		return ctrl.Result{}, err
+	}
+	if hold != nil {
+		return r.holdRemediation(ctx, remediation, hold)
+	}
+
+	logger.Info("Maintenance window no longer applies, resuming remediation")
+	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseAnalyzing
+	remediation.Status.Reason = "Maintenance window closed, resuming"
+	now := metav1.Now()
+	remediation.Status.LastUpdateTime = &now
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               ConditionMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: now,
+		Reason:             "WindowClosed",
+		Message:            "No maintenance window holds this remediation",
+	})
+
+	if err := r.Status().Update(ctx, remediation); err != nil {
+		logger.Error(err, "Failed to update status to Analyzing")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// ttlRemaining returns how long remediation has left before its TTL runs
+// out. ok is false if it has no valid TTL.
+func ttlRemaining(remediation *k8shealerv1alpha1.Remediation) (time.Duration, bool) {
+	if remediation.Spec.Strategy.TTL == "" {
+		return 0, false
+	}
+	ttl, err := time.ParseDuration(remediation.Spec.Strategy.TTL)
+	if err != nil {
+		return 0, false
+	}
+	return ttl - time.Since(remediation.CreationTimestamp.Time), true
+}
+
+// awaitingPR reports whether remediation has passed the window check and is
+// waiting in Pending for the GitHub App to open a PR
+func awaitingPR(remediation *k8shealerv1alpha1.Remediation) bool {
+	return remediation.Status.Phase == k8shealerv1alpha1.RemediationPhasePending &&
+		meta.IsStatusConditionFalse(remediation.Status.Conditions, ConditionMaintenanceWindow)
+}
+
+// recheckAwaitingPR puts a Remediation awaiting a PR on hold as soon as a
+// window holds its target, so the GitHub App stops seeing it
+func (r *RemediationReconciler) recheckAwaitingPR(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	hold, next, err := r.checkMaintenanceWindows(ctx, remediation)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to check maintenance windows")
+		return ctrl.Result{}, err
+	}
+	if hold != nil {
+		return r.holdRemediation(ctx, remediation, hold)
+	}
+	return ctrl.Result{RequeueAfter: windowRecheckAfter(next)}, nil
+}
+
+// windowRecheckAfter returns how long to wait before evaluating the windows
+// again, given when one next opens or closes
+func windowRecheckAfter(next time.Time) time.Duration {
+	requeue := holdRecheckInterval
+	if !next.IsZero() {
+		if untilNext := time.Until(next); untilNext < requeue {
+			requeue = max(untilNext, time.Second)
+		}
+	}
+	return requeue
+}
+
+// remediationsOnHold maps a MaintenanceWindow change to the Remediations it
+// may release or hold: those on hold and those awaiting a PR
+func (r *RemediationReconciler) remediationsOnHold(ctx context.Context, _ client.Object) []reconcile.Request {
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := r.List(ctx, list); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list remediations for maintenance window change")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, item := range list.Items {
+		if item.Status.Phase == k8shealerv1alpha1.RemediationPhaseOnHold || awaitingPR(&item) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&item)})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/maintenance"
+)
+
+// MaintenanceWindowReconciler keeps MaintenanceWindow status up to date
+type MaintenanceWindowReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=maintenancewindows,verbs=get;list;watch
+// +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=maintenancewindows/status,verbs=get;update;patch
+
+// Reconcile records whether the window is open and requeues at its next transition
+func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	window := &k8shealerv1alpha1.MaintenanceWindow{}
+	if err := r.Get(ctx, req.NamespacedName, window); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get MaintenanceWindow")
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	status := k8shealerv1alpha1.MaintenanceWindowStatus{}
+	state, err := maintenance.Evaluate(window, now)
+	if err != nil {
+		status.Error = err.Error()
+	} else {
+		status.Active = state.Active
+		if !state.NextTransition.IsZero() {
+			next := metav1.NewTime(state.NextTransition)
+			status.NextTransitionTime = &next
+		}
+	}
+
+	if !maintenanceWindowStatusEqual(window.Status, status) {
+		if status.Active != window.Status.Active {
+			logger.Info("Maintenance window changed state", "active", status.Active)
+		}
+		window.Status = status
+		if err := r.Status().Update(ctx, window); err != nil {
+			logger.Error(err, "Failed to update MaintenanceWindow status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	if status.NextTransitionTime == nil {
+		return ctrl.Result{}, nil
+	}
+	// Wake up just after the transition so it has happened
+	return ctrl.Result{RequeueAfter: status.NextTransitionTime.Sub(now) + time.Second}, nil
+}
+
+func maintenanceWindowStatusEqual(a, b k8shealerv1alpha1.MaintenanceWindowStatus) bool {
+	if a.Active != b.Active || a.Error != b.Error {
+		return false
+	}
+	if a.NextTransitionTime == nil || b.NextTransitionTime == nil {
+		return a.NextTransitionTime == nil && b.NextTransitionTime == nil
+	}
+	return a.NextTransitionTime.Equal(b.NextTransitionTime)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&k8shealerv1alpha1.MaintenanceWindow{}).
+		Complete(r)
+}
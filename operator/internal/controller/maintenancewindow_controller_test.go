@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestMaintenanceWindowReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	end := metav1.NewTime(time.Now().Add(time.Hour).Truncate(time.Second))
+	open := &k8shealerv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-freeze"},
+		Spec: k8shealerv1alpha1.MaintenanceWindowSpec{
+			Type:   k8shealerv1alpha1.MaintenanceWindowTypeFreeze,
+			Ranges: []k8shealerv1alpha1.TimeRange{{Start: metav1.NewTime(time.Now().Add(-time.Hour)), End: end}},
+		},
+	}
+	invalid := &k8shealerv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "typo"},
+		Spec: k8shealerv1alpha1.MaintenanceWindowSpec{
+			Type:     k8shealerv1alpha1.MaintenanceWindowTypeAllowedOnly,
+			Schedule: "0 25 * * *",
+			Duration: "1h",
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(scheme).WithObjects(open, invalid).WithStatusSubresource(open, invalid).Build()
+	r := &MaintenanceWindowReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "release-freeze"}})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Hour+time.Second {
+		t.Errorf("Expected a requeue at the end of the window, got %s", result.RequeueAfter)
+	}
+	updated := &k8shealerv1alpha1.MaintenanceWindow{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "release-freeze"}, updated); err != nil {
+		t.Fatalf("Failed to get window: %v", err)
+	}
+	if !updated.Status.Active || updated.Status.NextTransitionTime == nil || !updated.Status.NextTransitionTime.Equal(&end) {
+		t.Errorf("Expected an active window closing at %s, got %+v", end, updated.Status)
+	}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "typo"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := client.Get(ctx, types.NamespacedName{Name: "typo"}, updated); err != nil {
+		t.Fatalf("Failed to get window: %v", err)
+	}
+	if updated.Status.Error == "" {
+		t.Error("Expected the schedule error in status")
+	}
+}
@@ -18,10 +18,12 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -29,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
@@ -40,13 +43,34 @@ import (
 type RemediationReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// Silencer, if set, silences the triggering alert after a direct
+	// remediation is applied
+	Silencer Silencer
+
+	// SilenceDuration is how long that silence lasts; DefaultSilenceDuration if zero
+	SilenceDuration time.Duration
+
+	// APIReader, if set, reads CustomScript Job templates and ConfigMaps
+	// without caching them; the client is used otherwise
+	APIReader client.Reader
+
+	// PodLogs, if set, reads the logs of finished CustomScript Jobs
+	PodLogs PodLogs
 }
 
 // +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=remediations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=remediations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=remediations/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
-// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=k8shealer.k8s-healer.io,resources=remediationjobtemplates,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=replicasets;controllerrevisions,verbs=get;list;watch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop
@@ -74,9 +98,13 @@ func (r *RemediationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.handleAnalyzingRemediation(ctx, remediation)
 	case k8shealerv1alpha1.RemediationPhaseApplying:
 		return r.handleApplyingRemediation(ctx, remediation)
+	case k8shealerv1alpha1.RemediationPhaseOnHold:
+		return r.handleOnHoldRemediation(ctx, remediation)
 	case k8shealerv1alpha1.RemediationPhaseSucceeded,
 		k8shealerv1alpha1.RemediationPhaseFailed,
-		k8shealerv1alpha1.RemediationPhaseExpired:
+		k8shealerv1alpha1.RemediationPhaseExpired,
+		k8shealerv1alpha1.RemediationPhaseCancelled,
+		k8shealerv1alpha1.RemediationPhaseThrottled:
 		// Terminal states - nothing to do
 		return ctrl.Result{}, nil
 	case k8shealerv1alpha1.RemediationPhasePRCreated:
@@ -92,6 +120,10 @@ func (r *RemediationReconciler) handleNewRemediation(ctx context.Context, remedi
 	logger := log.FromContext(ctx)
 	logger.Info("Handling new remediation")
 
+	if reason, ok := remediation.Annotations[remediate.AnnotationThrottled]; ok {
+		return r.updateStatusToThrottled(ctx, remediation, reason)
+	}
+
 	// Record for dashboard (alert/remediation created)
 	dashboard.RecordAlertReceived(
 		remediation.Spec.Alert.Name,
@@ -129,6 +161,16 @@ func (r *RemediationReconciler) handlePendingRemediation(ctx context.Context, re
 	logger := log.FromContext(ctx)
 	logger.Info("Handling pending remediation")
 
+	// Already analyzed and waiting for the GitHub App
+	if awaitingPR(remediation) {
+		return r.recheckAwaitingPR(ctx, remediation)
+	}
+
+	// Alerts that only carry a pod label target the pod's owning workload
+	if remediation.Spec.Target.Kind == "Pod" {
+		return r.resolvePodTarget(ctx, remediation)
+	}
+
 	// Validate target resource exists
 	targetKey := client.ObjectKey{
 		Namespace: remediation.Spec.Target.Namespace,
@@ -143,6 +185,10 @@ func (r *RemediationReconciler) handlePendingRemediation(ctx context.Context, re
 		targetObj = &appsv1.StatefulSet{}
 	case "DaemonSet":
 		targetObj = &appsv1.DaemonSet{}
+	case "Job":
+		targetObj = &batchv1.Job{}
+	case "CronJob":
+		targetObj = &batchv1.CronJob{}
 	default:
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Unsupported target kind: %s", remediation.Spec.Target.Kind))
 	}
@@ -169,35 +215,163 @@ func (r *RemediationReconciler) handlePendingRemediation(ctx context.Context, re
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// resolvePodTarget replaces a Pod target with the workload that owns the pod
+func (r *RemediationReconciler) resolvePodTarget(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	target := remediation.Spec.Target
+
+	kind, name, err := remediate.ResolvePodOwner(ctx, r.Client, target.Namespace, target.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Cannot resolve target: %v", err))
+		}
+		if errors.Is(err, remediate.ErrUnsupportedOwner) {
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Cannot remediate pod %s/%s: %v", target.Namespace, target.Name, err))
+		}
+		logger.Error(err, "Failed to resolve pod owner")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Resolved pod to owning workload", "pod", target.Name, "kind", kind, "name", name)
+
+	remediation.Spec.Target.Kind = kind
+	remediation.Spec.Target.Name = name
+	if remediation.Labels == nil {
+		remediation.Labels = map[string]string{}
+	}
+	remediation.Labels["k8s-healer.io/target"] = name
+	remediation.Labels["k8s-healer.io/target-kind"] = kind
+
+	if err := r.Update(ctx, remediation); err != nil {
+		logger.Error(err, "Failed to update Remediation target")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{Requeue: true}, nil
+}
+
 func (r *RemediationReconciler) handleAnalyzingRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Handling analyzing remediation")
 
+	// Never stack changes: wait while another remediation is changing the same workload
+	inFlight, err := r.findInFlightRemediation(ctx, remediation)
+	if err != nil {
+		logger.Error(err, "Failed to list remediations for target")
+		return ctrl.Result{}, err
+	}
+	if inFlight != "" {
+		return r.waitForInFlightRemediation(ctx, remediation, inFlight)
+	}
+
+	// No Direct applies and no PRs while a maintenance window holds the target
+	hold, nextWindowChange, err := r.checkMaintenanceWindows(ctx, remediation)
+	if err != nil {
+		logger.Error(err, "Failed to check maintenance windows")
+		return ctrl.Result{}, err
+	}
+	if hold != nil {
+		return r.holdRemediation(ctx, remediation, hold)
+	}
+
 	// Check remediation strategy
 	if remediation.Spec.Strategy.Mode == k8shealerv1alpha1.StrategyModeDirect && !remediation.Spec.Strategy.RequireApproval {
 		// Direct mode without approval - apply immediately
 		return r.handleDirectRemediation(ctx, remediation)
 	}
 
-	// GitOps mode - wait for external service
+	// Restarts and scripts leave nothing to change in Git
+	switch remediation.Spec.Action.Type {
+	case k8shealerv1alpha1.ActionTypeRestartPods, k8shealerv1alpha1.ActionTypeCustomScript:
+		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("%s is only supported in Direct mode without approval", remediation.Spec.Action.Type))
+	}
+
+	// GitOps mode - wait for external service. The GitHub App only picks up
+	// Remediations marked with a false MaintenanceWindow condition.
 	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhasePending
 	remediation.Status.Reason = "Waiting for GitHub App service to create PR"
 	now := metav1.Now()
 	remediation.Status.LastUpdateTime = &now
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               ConditionMaintenanceWindow,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: now,
+		Reason:             "NoWindowActive",
+		Message:            "No maintenance window holds this remediation",
+	})
 
 	if err := r.Status().Update(ctx, remediation); err != nil {
 		logger.Error(err, "Failed to update status")
 		return ctrl.Result{}, err
 	}
 
-	// External service will watch this and create PR
-	return ctrl.Result{}, nil
+	// External service will watch this and create PR. Check the windows
+	// again when one opens, in case the PR is still not created by then.
+	return ctrl.Result{RequeueAfter: windowRecheckAfter(nextWindowChange)}, nil
+}
+
+// findInFlightRemediation returns the name of another Remediation that is
+// currently changing the same target (PR requested or open, or being
+// applied), if any
+func (r *RemediationReconciler) findInFlightRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (string, error) {
+	target := remediation.Spec.Target
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := r.List(ctx, list,
+		client.InNamespace(remediation.Namespace),
+		client.MatchingLabels{"k8s-healer.io/target": target.Name},
+	); err != nil {
+		return "", err
+	}
+
+	for _, other := range list.Items {
+		if other.Name == remediation.Name ||
+			other.Spec.Target.Kind != target.Kind ||
+			other.Spec.Target.Namespace != target.Namespace ||
+			other.Spec.Target.Name != target.Name {
+			continue
+		}
+		switch other.Status.Phase {
+		case k8shealerv1alpha1.RemediationPhaseApplying,
+			k8shealerv1alpha1.RemediationPhasePRCreated:
+			return other.Name, nil
+		}
+		if awaitingPR(&other) {
+			return other.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *RemediationReconciler) waitForInFlightRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation, inFlight string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	reason := fmt.Sprintf("Waiting for remediation %s on the same target to finish", inFlight)
+	if remediation.Status.Reason != reason {
+		logger.Info("Another remediation is in progress for the target, waiting", "inFlight", inFlight)
+		remediation.Status.Reason = reason
+		now := metav1.Now()
+		remediation.Status.LastUpdateTime = &now
+		if err := r.Status().Update(ctx, remediation); err != nil {
+			logger.Error(err, "Failed to update status")
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 }
 
 func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Applying direct remediation")
 
+	// Scripts run as a Job instead of changing the target
+	if remediation.Spec.Action.Type == k8shealerv1alpha1.ActionTypeCustomScript {
+		return r.startScriptJob(ctx, remediation)
+	}
+
 	// Get target resource
 	targetKey := client.ObjectKey{
 		Namespace: remediation.Spec.Target.Namespace,
@@ -208,6 +382,10 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 	switch remediation.Spec.Target.Kind {
 	case "Deployment":
 		targetObj = &appsv1.Deployment{}
+	case "StatefulSet":
+		targetObj = &appsv1.StatefulSet{}
+	case "DaemonSet":
+		targetObj = &appsv1.DaemonSet{}
 	default:
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Direct remediation not implemented for kind: %s", remediation.Spec.Target.Kind))
 	}
@@ -216,25 +394,31 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to get target: %v", err))
 	}
 
-	// Capture "before" state for dashboard (e.g. memory limit)
+	// Capture "before" state for dashboard (e.g. memory or CPU limit)
 	var detailsBefore string
-	if dep, ok := targetObj.(*appsv1.Deployment); ok && remediation.Spec.Action.Type == k8shealerv1alpha1.ActionTypeIncreaseMemory {
-		detailsBefore = getContainerMemoryLimit(dep, remediation.Spec.Target.Container)
+	if dep, ok := targetObj.(*appsv1.Deployment); ok {
+		switch remediation.Spec.Action.Type {
+		case k8shealerv1alpha1.ActionTypeIncreaseMemory, k8shealerv1alpha1.ActionTypeIncreaseCPU:
+			name, _ := limitChanged(remediation.Spec.Action.Type)
+			detailsBefore = getContainerLimit(dep, remediation.Spec.Target.Container, name)
+		}
 	}
 
-	// Apply remediation based on action type
+	// Apply remediation based on action type. A rollback also reports the
+	// revision it restored.
+	var rollback *k8shealerv1alpha1.RollbackStatus
 	var err error
-	switch remediation.Spec.Action.Type {
-	case k8shealerv1alpha1.ActionTypeIncreaseMemory:
-		err = remediate.ApplyIncreaseMemory(targetObj, remediation.Spec.Target.Container, remediation.Spec.Action.Params)
-	case k8shealerv1alpha1.ActionTypeScaleUp:
-		err = remediate.ApplyScaleUp(targetObj, remediation.Spec.Action.Params)
-	case k8shealerv1alpha1.ActionTypeRollbackImage:
-		err = remediate.ApplyRollbackImage(ctx, r.Client, targetObj, remediation.Spec.Action.Params)
-	default:
+	if remediation.Spec.Action.Type == k8shealerv1alpha1.ActionTypeRollbackImage {
+		rollback, err = remediate.ApplyRollbackImage(ctx, r.Client, targetObj, remediation.Spec.Target.Container, remediation.Spec.Action.Params)
+	} else {
+		err = remediate.ApplyAction(ctx, r.Client, targetObj, remediation.Spec.Target, remediation.Spec.Action)
+	}
+	if errors.Is(err, remediate.ErrUnsupportedAction) {
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Unsupported action type: %s", remediation.Spec.Action.Type))
 	}
-
+	if errors.Is(err, remediate.ErrRestartCooldown) {
+		return r.updateStatusToThrottled(ctx, remediation, fmt.Sprintf("Not restarting %s %s: %v", remediation.Spec.Target.Kind, remediation.Spec.Target.Name, err))
+	}
 	if err != nil {
 		dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), err.Error())
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to calculate remediation: %v", err))
@@ -247,11 +431,30 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 	remediation.Status.LastUpdateTime = &now
 	remediation.Status.Attempts++
 
+	// Keep the alert quiet while pods restart with the change. The silence
+	// is recorded with Applying so a failure below lifts it again; if it
+	// cannot be recorded it is lifted right away.
+	silenced := r.silenceAlert(ctx, remediation)
+
 	if err := r.Status().Update(ctx, remediation); err != nil {
 		logger.Error(err, "Failed to update status to Applying")
+		if silenced {
+			r.expireSilence(ctx, remediation)
+		}
 		return ctrl.Result{}, err
 	}
 
+	// A pod-scoped restart deletes the alert's pod, but only after the
+	// restart is recorded on the workload so its cooldown applies
+	var restartPod *corev1.Pod
+	if remediation.Spec.Action.Type == k8shealerv1alpha1.ActionTypeRestartPods &&
+		remediate.RestartScope(remediation.Spec.Action.Params) == remediate.RestartScopePod {
+		if restartPod, err = r.alertPod(ctx, remediation); err != nil {
+			dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), err.Error())
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to restart pod: %v", err))
+		}
+	}
+
 	// Apply the patch
 	if err := r.Update(ctx, targetObj); err != nil {
 		dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), err.Error())
@@ -259,8 +462,18 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to apply remediation: %v", err))
 	}
 
+	if restartPod != nil {
+		if err := r.deletePod(ctx, restartPod); err != nil {
+			dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), err.Error())
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to restart pod: %v", err))
+		}
+	}
+
 	// Record for dashboard (what changed)
 	details := buildAppliedDetails(targetObj, remediation.Spec.Target.Container, remediation.Spec.Action.Type, detailsBefore)
+	if rollback != nil {
+		details = fmt.Sprintf("rolled back to revision %d", rollback.Revision)
+	}
 	dashboard.RecordRemediationApplied(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), details)
 
 	// Update to Succeeded
@@ -270,6 +483,7 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 	remediation.Status.AppliedAt = &applied
 	remediation.Status.ResolvedAt = &applied
 	remediation.Status.LastUpdateTime = &applied
+	remediation.Status.Rollback = rollback
 
 	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
 		Type:               "Applied",
@@ -290,6 +504,10 @@ func (r *RemediationReconciler) handleDirectRemediation(ctx context.Context, rem
 }
 
 func (r *RemediationReconciler) handleApplyingRemediation(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	if remediation.Status.JobName != "" {
+		return r.trackScriptJob(ctx, remediation)
+	}
+
 	// This phase is used when GitHub Actions applies the remediation
 	// For now, we just wait for external update
 	return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
@@ -298,13 +516,8 @@ func (r *RemediationReconciler) handleApplyingRemediation(ctx context.Context, r
 func (r *RemediationReconciler) handlePRCreated(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
 	// PR is created, waiting for merge and application
 	// Check TTL
-	if remediation.Spec.Strategy.TTL != "" {
-		ttl, err := time.ParseDuration(remediation.Spec.Strategy.TTL)
-		if err == nil {
-			if time.Since(remediation.CreationTimestamp.Time) > ttl {
-				return r.updateStatusToExpired(ctx, remediation, "Remediation TTL exceeded")
-			}
-		}
+	if remaining, ok := ttlRemaining(remediation); ok && remaining < 0 {
+		return r.updateStatusToExpired(ctx, remediation, "Remediation TTL exceeded")
 	}
 
 	return ctrl.Result{RequeueAfter: 1 * time.Minute}, nil
@@ -314,6 +527,8 @@ func (r *RemediationReconciler) updateStatusToFailed(ctx context.Context, remedi
 	logger := log.FromContext(ctx)
 	logger.Info("Updating remediation status to Failed", "reason", reason)
 
+	r.expireSilence(ctx, remediation)
+
 	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseFailed
 	remediation.Status.Reason = reason
 	now := metav1.Now()
@@ -337,6 +552,33 @@ func (r *RemediationReconciler) updateStatusToFailed(ctx context.Context, remedi
 	return ctrl.Result{}, nil
 }
 
+func (r *RemediationReconciler) updateStatusToThrottled(ctx context.Context, remediation *k8shealerv1alpha1.Remediation, reason string) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("Remediation is over its rate limit, not processing it", "reason", reason)
+
+	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseThrottled
+	remediation.Status.Reason = reason
+	now := metav1.Now()
+	remediation.Status.LastUpdateTime = &now
+	remediation.Status.ResolvedAt = &now
+
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               "Throttled",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: now,
+		Reason:             "RateLimited",
+		Message:            reason,
+	})
+
+	if err := r.Status().Update(ctx, remediation); err != nil {
+		logger.Error(err, "Failed to update status to Throttled")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
 func (r *RemediationReconciler) updateStatusToExpired(ctx context.Context, remediation *k8shealerv1alpha1.Remediation, reason string) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
 	logger.Info("Updating remediation status to Expired", "reason", reason)
@@ -355,12 +597,12 @@ func (r *RemediationReconciler) updateStatusToExpired(ctx context.Context, remed
 	return ctrl.Result{}, nil
 }
 
-func getContainerMemoryLimit(dep *appsv1.Deployment, containerName string) string {
+func getContainerLimit(dep *appsv1.Deployment, containerName string, name corev1.ResourceName) string {
 	for _, c := range dep.Spec.Template.Spec.Containers {
 		if containerName != "" && c.Name != containerName {
 			continue
 		}
-		if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+		if q, ok := c.Resources.Limits[name]; ok {
 			return q.String()
 		}
 		return "0"
@@ -368,19 +610,35 @@ func getContainerMemoryLimit(dep *appsv1.Deployment, containerName string) strin
 	return ""
 }
 
+// limitChanged returns the resource whose limit actionType changes and how it
+// is described
+func limitChanged(actionType k8shealerv1alpha1.ActionType) (corev1.ResourceName, string) {
+	if actionType == k8shealerv1alpha1.ActionTypeIncreaseCPU {
+		return corev1.ResourceCPU, "CPU limit"
+	}
+	return corev1.ResourceMemory, "memory limit"
+}
+
 func buildAppliedDetails(obj client.Object, containerName string, actionType k8shealerv1alpha1.ActionType, before string) string {
+	if actionType == k8shealerv1alpha1.ActionTypeRestartPods {
+		return "pods restarted"
+	}
+	name, label := limitChanged(actionType)
 	switch v := obj.(type) {
 	case *appsv1.Deployment:
 		for _, c := range v.Spec.Template.Spec.Containers {
 			if containerName != "" && c.Name != containerName {
 				continue
 			}
-			if q, ok := c.Resources.Limits[corev1.ResourceMemory]; ok {
+			if q, ok := c.Resources.Limits[name]; ok {
 				after := q.String()
 				if before != "" {
-					return "memory limit " + before + " → " + after
+					return label + " " + before + " → " + after
 				}
-				return "memory limit " + after
+				return label + " " + after
+			}
+			if actionType == k8shealerv1alpha1.ActionTypeIncreaseCPU && before != "" {
+				return label + " " + before + " removed"
 			}
 			break
 		}
@@ -392,5 +650,9 @@ func buildAppliedDetails(obj client.Object, containerName string, actionType k8s
 func (r *RemediationReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&k8shealerv1alpha1.Remediation{}).
+		// Finish CustomScript remediations as soon as their Job does
+		Owns(&batchv1.Job{}).
+		// Re-evaluate held remediations as soon as a window is changed or deleted
+		Watches(&k8shealerv1alpha1.MaintenanceWindow{}, handler.EnqueueRequestsFromMapFunc(r.remediationsOnHold)).
 		Complete(r)
 }
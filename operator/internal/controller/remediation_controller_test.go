@@ -2,10 +2,13 @@ package controller
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -14,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
 )
 
 func TestRemediationReconciler_IncreaseMemory(t *testing.T) {
@@ -245,6 +249,507 @@ func TestRemediationReconciler_ScaleUp(t *testing.T) {
 	}
 }
 
+func TestRemediationReconciler_ResolvePodTarget(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	isController := true
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-5f7b8c9d",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: "dep-uid", Controller: &isController},
+			},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-5f7b8c9d-xyz",
+			Namespace: "default",
+			OwnerReferences: []metav1.OwnerReference{
+				{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5f7b8c9d", UID: "rs-uid", Controller: &isController},
+			},
+		},
+	}
+	standalone := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "default"},
+	}
+
+	newPodRemediation := func(name, podName string) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: "Pod", Name: podName, Namespace: "default"},
+				Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhasePending},
+		}
+	}
+	resolvable := newPodRemediation("rem-api", "api-5f7b8c9d-xyz")
+	unresolvable := newPodRemediation("rem-debug", "debug")
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(replicaSet, pod, standalone, resolvable, unresolvable).
+		WithStatusSubresource(resolvable, unresolvable).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+
+	// Pod owned by a Deployment's ReplicaSet targets the Deployment
+	key := types.NamespacedName{Name: "rem-api", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Spec.Target.Kind != "Deployment" || updated.Spec.Target.Name != "api" {
+		t.Errorf("Expected target Deployment/api, got %s/%s", updated.Spec.Target.Kind, updated.Spec.Target.Name)
+	}
+
+	// Pod without a supported owner fails with an explicit reason
+	key = types.NamespacedName{Name: "rem-debug", Namespace: "default"}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseFailed {
+		t.Errorf("Expected phase Failed, got %s", updated.Status.Phase)
+	}
+	if updated.Status.Reason == "" {
+		t.Error("Expected a failure reason")
+	}
+}
+
+func TestRemediationReconciler_WaitsForInFlightRemediation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	newRemediation := func(name string, phase k8shealerv1alpha1.RemediationPhase) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"k8s-healer.io/target": "api"},
+			},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default", Container: "app"},
+				Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+				Strategy: k8shealerv1alpha1.Strategy{
+					Mode: k8shealerv1alpha1.StrategyModeDirect,
+				},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: phase},
+		}
+	}
+	inFlight := newRemediation("rem-pr", k8shealerv1alpha1.RemediationPhasePRCreated)
+	waiting := newRemediation("rem-direct", k8shealerv1alpha1.RemediationPhaseAnalyzing)
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, inFlight, waiting).
+		WithStatusSubresource(inFlight, waiting).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-direct", Namespace: "default"}
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("Expected a requeue while another remediation is in flight")
+	}
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseAnalyzing {
+		t.Errorf("Expected phase Analyzing, got %s", updated.Status.Phase)
+	}
+	live := &appsv1.Deployment{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "api", Namespace: "default"}, live); err != nil {
+		t.Fatalf("Failed to get deployment: %v", err)
+	}
+	if limit := live.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]; limit.String() != "256Mi" {
+		t.Errorf("Expected deployment unchanged, got memory limit %s", limit.String())
+	}
+
+	// Once the other remediation finishes, this one is applied
+	if err := client.Get(ctx, types.NamespacedName{Name: "rem-pr", Namespace: "default"}, inFlight); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	inFlight.Status.Phase = k8shealerv1alpha1.RemediationPhaseSucceeded
+	if err := client.Status().Update(ctx, inFlight); err != nil {
+		t.Fatalf("Failed to update remediation: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Errorf("Expected phase Succeeded, got %s", updated.Status.Phase)
+	}
+}
+
+func TestRemediationReconciler_SerializesGitOpsRemediations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	newRemediation := func(name string, action k8shealerv1alpha1.ActionType) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"k8s-healer.io/target": "api"},
+			},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target:   k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default", Container: "app"},
+				Action:   k8shealerv1alpha1.Action{Type: action},
+				Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeGitOps},
+				GitHub:   &k8shealerv1alpha1.GitHubConfig{Enabled: true},
+			},
+		}
+	}
+	first := newRemediation("rem-memory", k8shealerv1alpha1.ActionTypeIncreaseMemory)
+	second := newRemediation("rem-cpu", k8shealerv1alpha1.ActionTypeIncreaseCPU)
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, first, second).
+		WithStatusSubresource(first, second).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	reconcileAndGet := func(name string, times int) *k8shealerv1alpha1.Remediation {
+		t.Helper()
+		key := types.NamespacedName{Name: name, Namespace: "default"}
+		for i := 0; i < times; i++ {
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+		}
+		updated := &k8shealerv1alpha1.Remediation{}
+		if err := client.Get(ctx, key, updated); err != nil {
+			t.Fatalf("Failed to get remediation: %v", err)
+		}
+		return updated
+	}
+
+	if updated := reconcileAndGet("rem-memory", 3); !awaitingPR(updated) {
+		t.Fatalf("Expected rem-memory to await a PR, got %s", updated.Status.Phase)
+	}
+
+	// The second one must not be handed to the GitHub App while the first PR is requested
+	updated := reconcileAndGet("rem-cpu", 3)
+	if awaitingPR(updated) || updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseAnalyzing {
+		t.Fatalf("Expected rem-cpu to wait in Analyzing, got %s", updated.Status.Phase)
+	}
+	if !strings.Contains(updated.Status.Reason, "rem-memory") {
+		t.Errorf("Expected the reason to name rem-memory, got %q", updated.Status.Reason)
+	}
+
+	// Once the first one is done, the second one goes to the GitHub App
+	first = reconcileAndGet("rem-memory", 0)
+	first.Status.Phase = k8shealerv1alpha1.RemediationPhaseSucceeded
+	if err := client.Status().Update(ctx, first); err != nil {
+		t.Fatalf("Failed to update remediation: %v", err)
+	}
+	if updated := reconcileAndGet("rem-cpu", 1); !awaitingPR(updated) {
+		t.Errorf("Expected rem-cpu to await a PR, got %s", updated.Status.Phase)
+	}
+}
+
+func TestRemediationReconciler_Throttled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "rem-throttled",
+			Namespace:   "default",
+			Annotations: map[string]string{remediate.AnnotationThrottled: "namespace default reached its limit of 1 remediations per 1h0m0s"},
+		},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default", Container: "app"},
+			Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+			Strategy: k8shealerv1alpha1.Strategy{
+				Mode: k8shealerv1alpha1.StrategyModeDirect,
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(remediation).
+		WithStatusSubresource(remediation).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-throttled", Namespace: "default"}
+
+	// A second reconcile must leave the terminal phase alone
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseThrottled {
+		t.Errorf("Expected phase Throttled, got %s", updated.Status.Phase)
+	}
+	if updated.Status.Reason != remediation.Annotations[remediate.AnnotationThrottled] {
+		t.Errorf("Expected the throttle reason in status, got %q", updated.Status.Reason)
+	}
+}
+
+func TestRemediationReconciler_MaintenanceWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "app",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+					}},
+				},
+			},
+		},
+	}
+	freeze := &k8shealerv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-freeze"},
+		Spec: k8shealerv1alpha1.MaintenanceWindowSpec{
+			Type: k8shealerv1alpha1.MaintenanceWindowTypeFreeze,
+			Ranges: []k8shealerv1alpha1.TimeRange{{
+				Start: metav1.NewTime(time.Now().Add(-time.Hour)),
+				End:   metav1.NewTime(time.Now().Add(time.Hour)),
+			}},
+		},
+	}
+	newRemediation := func(name, ttl string) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-10 * time.Minute)),
+			},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default", Container: "app"},
+				Action: k8shealerv1alpha1.Action{
+					Type:   k8shealerv1alpha1.ActionTypeIncreaseMemory,
+					Params: map[string]string{"memoryIncreasePercent": "25", "maxMemory": "1Gi"},
+				},
+				Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect, TTL: ttl},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+		}
+	}
+	held := newRemediation("rem-held", "24h")
+	expiring := newRemediation("rem-expiring", "5m")
+	expiring.Status.Phase = k8shealerv1alpha1.RemediationPhaseOnHold
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, freeze, held, expiring).
+		WithStatusSubresource(held, expiring, freeze).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-held", Namespace: "default"}
+
+	result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("Expected a requeue while on hold")
+	}
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseOnHold {
+		t.Errorf("Expected phase OnHold, got %s", updated.Status.Phase)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, ConditionMaintenanceWindow)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "Freeze" {
+		t.Errorf("Expected a true MaintenanceWindow condition with reason Freeze, got %+v", condition)
+	}
+	live := &appsv1.Deployment{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "api", Namespace: "default"}, live); err != nil {
+		t.Fatalf("Failed to get deployment: %v", err)
+	}
+	if limit := live.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]; limit.String() != "256Mi" {
+		t.Errorf("Expected deployment unchanged during the freeze, got memory limit %s", limit.String())
+	}
+
+	// The TTL keeps running while on hold
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "rem-expiring", Namespace: "default"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := client.Get(ctx, types.NamespacedName{Name: "rem-expiring", Namespace: "default"}, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseExpired {
+		t.Errorf("Expected phase Expired, got %s", updated.Status.Phase)
+	}
+
+	// Ending the freeze resumes the remediation
+	if err := client.Delete(ctx, freeze); err != nil {
+		t.Fatalf("Failed to delete maintenance window: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+	}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Errorf("Expected phase Succeeded, got %s (%s)", updated.Status.Phase, updated.Status.Reason)
+	}
+	condition = meta.FindStatusCondition(updated.Status.Conditions, ConditionMaintenanceWindow)
+	if condition == nil || condition.Status != metav1.ConditionFalse {
+		t.Errorf("Expected a false MaintenanceWindow condition, got %+v", condition)
+	}
+}
+
 func ptr(i int32) *int32 {
 	return &i
 }
+
+func TestRemediationReconciler_MaintenanceWindowGitOps(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"}}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-gitops", Namespace: "default"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Target:   k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default", Container: "app"},
+			Action:   k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeGitOps},
+			GitHub:   &k8shealerv1alpha1.GitHubConfig{Enabled: true},
+		},
+	}
+	// A freeze that starts while the remediation waits for its PR
+	freeze := &k8shealerv1alpha1.MaintenanceWindow{
+		ObjectMeta: metav1.ObjectMeta{Name: "release-freeze"},
+		Spec: k8shealerv1alpha1.MaintenanceWindowSpec{
+			Type: k8shealerv1alpha1.MaintenanceWindowTypeFreeze,
+			Ranges: []k8shealerv1alpha1.TimeRange{{
+				Start: metav1.NewTime(time.Now().Add(time.Minute)),
+				End:   metav1.NewTime(time.Now().Add(time.Hour)),
+			}},
+		},
+	}
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, remediation, freeze).
+		WithStatusSubresource(remediation, freeze).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-gitops", Namespace: "default"}
+	updated := &k8shealerv1alpha1.Remediation{}
+	reconcileAndGet := func() reconcile.Result {
+		t.Helper()
+		result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+		if err != nil {
+			t.Fatalf("Reconcile failed: %v", err)
+		}
+		if err := client.Get(ctx, key, updated); err != nil {
+			t.Fatalf("Failed to get remediation: %v", err)
+		}
+		return result
+	}
+
+	// A new remediation is Pending before its windows are checked, so the
+	// GitHub App must not see it yet
+	reconcileAndGet()
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhasePending || awaitingPR(updated) {
+		t.Fatalf("Expected Pending without a window check, got %s and %+v", updated.Status.Phase, updated.Status.Conditions)
+	}
+
+	var result reconcile.Result
+	for i := 0; i < 2; i++ {
+		result = reconcileAndGet()
+	}
+	if !awaitingPR(updated) {
+		t.Fatalf("Expected the remediation to await a PR, got %s and %+v", updated.Status.Phase, updated.Status.Conditions)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > time.Minute {
+		t.Errorf("Expected a recheck by the time the freeze starts, got %s", result.RequeueAfter)
+	}
+
+	// Further reconciles while waiting leave it parked
+	reconcileAndGet()
+	if !awaitingPR(updated) {
+		t.Fatalf("Expected the remediation to keep awaiting a PR, got %s", updated.Status.Phase)
+	}
+
+	// Once the freeze is in effect it is taken away from the GitHub App
+	freeze.Spec.Ranges[0].Start = metav1.NewTime(time.Now().Add(-time.Minute))
+	if err := client.Update(ctx, freeze); err != nil {
+		t.Fatalf("Failed to update maintenance window: %v", err)
+	}
+	if requests := r.remediationsOnHold(ctx, freeze); len(requests) != 1 || requests[0].NamespacedName != key {
+		t.Errorf("Expected the window change to enqueue the remediation awaiting a PR, got %v", requests)
+	}
+	reconcileAndGet()
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseOnHold {
+		t.Errorf("Expected phase OnHold, got %s", updated.Status.Phase)
+	}
+	if awaitingPR(updated) {
+		t.Error("Expected the held remediation not to await a PR")
+	}
+}
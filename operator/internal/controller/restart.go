@@ -0,0 +1,79 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// alertPod returns the pod named in remediation's alert. The pod must belong
+// to the target workload. A pod that is already gone or terminating is left
+// alone and nil is returned.
+func (r *RemediationReconciler) alertPod(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (*corev1.Pod, error) {
+	logger := log.FromContext(ctx)
+	target := remediation.Spec.Target
+
+	labels, err := alertLabels(remediation)
+	if err != nil {
+		return nil, err
+	}
+	podName := labels["pod"]
+	if podName == "" {
+		return nil, fmt.Errorf("alert has no pod label")
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: podName}, pod); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("Pod from the alert is already gone", "pod", podName)
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get pod %s: %w", podName, err)
+	}
+	if pod.DeletionTimestamp != nil {
+		logger.Info("Pod from the alert is already terminating", "pod", podName)
+		return nil, nil
+	}
+
+	// Never delete a pod outside the workload this remediation may change
+	kind, name, err := remediate.ResolvePodOwner(ctx, r.Client, target.Namespace, podName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve owner of pod %s: %w", podName, err)
+	}
+	if kind != target.Kind || name != target.Name {
+		return nil, fmt.Errorf("pod %s belongs to %s %s, not %s %s", podName, kind, name, target.Kind, target.Name)
+	}
+	return pod, nil
+}
+
+// deletePod deletes pod so its workload replaces it
+func (r *RemediationReconciler) deletePod(ctx context.Context, pod *corev1.Pod) error {
+	if err := r.Delete(ctx, pod, client.Preconditions{UID: &pod.UID}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete pod %s: %w", pod.Name, err)
+	}
+	log.FromContext(ctx).Info("Deleted pod from the alert", "pod", pod.Name)
+	return nil
+}
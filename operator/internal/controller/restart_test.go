@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestRemediationReconciler_RestartPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	isController := true
+	ownedBy := func(kind, name string) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: types.UID("uid-" + name), Controller: &isController}}
+	}
+	recentRestart := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name            string
+		target          string
+		mode            k8shealerv1alpha1.StrategyMode
+		params          map[string]string
+		podLabel        string
+		failUpdate      bool
+		expectPhase     k8shealerv1alpha1.RemediationPhase
+		expectPodGone   bool
+		expectRolled    bool
+		expectAnnotated bool
+	}{
+		{
+			name:            "rolling restart of the workload",
+			target:          "api",
+			mode:            k8shealerv1alpha1.StrategyModeDirect,
+			podLabel:        "api-5f7b8c9d-xyz",
+			expectPhase:     k8shealerv1alpha1.RemediationPhaseSucceeded,
+			expectRolled:    true,
+			expectAnnotated: true,
+		},
+		{
+			name:            "deletes only the alert's pod",
+			target:          "api",
+			mode:            k8shealerv1alpha1.StrategyModeDirect,
+			params:          map[string]string{"restartScope": remediate.RestartScopePod},
+			podLabel:        "api-5f7b8c9d-xyz",
+			expectPhase:     k8shealerv1alpha1.RemediationPhaseSucceeded,
+			expectPodGone:   true,
+			expectAnnotated: true,
+		},
+		{
+			name:        "keeps the pod when the restart cannot be recorded",
+			target:      "api",
+			mode:        k8shealerv1alpha1.StrategyModeDirect,
+			params:      map[string]string{"restartScope": remediate.RestartScopePod},
+			podLabel:    "api-5f7b8c9d-xyz",
+			failUpdate:  true,
+			expectPhase: k8shealerv1alpha1.RemediationPhaseFailed,
+		},
+		{
+			name:        "refuses a pod of another workload",
+			target:      "api",
+			mode:        k8shealerv1alpha1.StrategyModeDirect,
+			params:      map[string]string{"restartScope": remediate.RestartScopePod},
+			podLabel:    "db-0",
+			expectPhase: k8shealerv1alpha1.RemediationPhaseFailed,
+		},
+		{
+			name:        "within cooldown",
+			target:      "worker",
+			mode:        k8shealerv1alpha1.StrategyModeDirect,
+			podLabel:    "api-5f7b8c9d-xyz",
+			expectPhase: k8shealerv1alpha1.RemediationPhaseThrottled,
+		},
+		{
+			name:        "not in GitOps mode",
+			target:      "api",
+			mode:        k8shealerv1alpha1.StrategyModeGitOps,
+			podLabel:    "api-5f7b8c9d-xyz",
+			expectPhase: k8shealerv1alpha1.RemediationPhaseFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remediation := &k8shealerv1alpha1.Remediation{
+				ObjectMeta: metav1.ObjectMeta{Name: "rem-restart", Namespace: "prod"},
+				Spec: k8shealerv1alpha1.RemediationSpec{
+					Alert: k8shealerv1alpha1.AlertInfo{
+						Name:    "WorkerStuck",
+						Payload: `{"labels":{"alertname":"WorkerStuck","namespace":"prod","pod":"` + tt.podLabel + `"}}`,
+					},
+					Target:   k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: tt.target, Namespace: "prod"},
+					Action:   k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeRestartPods, Params: tt.params},
+					Strategy: k8shealerv1alpha1.Strategy{Mode: tt.mode},
+				},
+				Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+			}
+			objs := []client.Object{
+				remediation,
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+					Name: "worker", Namespace: "prod",
+					Annotations: map[string]string{remediate.AnnotationLastRestart: recentRestart},
+				}},
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "api-5f7b8c9d", Namespace: "prod", OwnerReferences: ownedBy("Deployment", "api")}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api-5f7b8c9d-xyz", Namespace: "prod", OwnerReferences: ownedBy("ReplicaSet", "api-5f7b8c9d")}},
+				&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "db-0", Namespace: "prod", OwnerReferences: ownedBy("StatefulSet", "db")}},
+			}
+
+			builder := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(remediation)
+			if tt.failUpdate {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{
+					Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+						if _, ok := obj.(*appsv1.Deployment); ok {
+							return apierrors.NewConflict(appsv1.Resource("deployments"), obj.GetName(), fmt.Errorf("object was modified"))
+						}
+						return c.Update(ctx, obj, opts...)
+					},
+				})
+			}
+			cl := builder.Build()
+			r := &RemediationReconciler{Client: cl, Scheme: scheme}
+			ctx := context.Background()
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: "rem-restart", Namespace: "prod"}}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+
+			updated := &k8shealerv1alpha1.Remediation{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(remediation), updated); err != nil {
+				t.Fatalf("Failed to get remediation: %v", err)
+			}
+			if updated.Status.Phase != tt.expectPhase {
+				t.Fatalf("Expected phase %s, got %s (%s)", tt.expectPhase, updated.Status.Phase, updated.Status.Reason)
+			}
+
+			err := cl.Get(ctx, types.NamespacedName{Name: "api-5f7b8c9d-xyz", Namespace: "prod"}, &corev1.Pod{})
+			if gone := apierrors.IsNotFound(err); gone != tt.expectPodGone {
+				t.Errorf("Expected pod deleted: %v, got error %v", tt.expectPodGone, err)
+			}
+			if err := cl.Get(ctx, types.NamespacedName{Name: "db-0", Namespace: "prod"}, &corev1.Pod{}); err != nil {
+				t.Errorf("Expected pod of another workload to remain: %v", err)
+			}
+
+			deployment := &appsv1.Deployment{}
+			if err := cl.Get(ctx, types.NamespacedName{Name: "api", Namespace: "prod"}, deployment); err != nil {
+				t.Fatalf("Failed to get deployment: %v", err)
+			}
+			if _, ok := deployment.Spec.Template.Annotations[remediate.AnnotationRestartedAt]; ok != tt.expectRolled {
+				t.Errorf("Expected restartedAt on the pod template: %v, got %v", tt.expectRolled, deployment.Spec.Template.Annotations)
+			}
+			if _, ok := deployment.Annotations[remediate.AnnotationLastRestart]; ok != tt.expectAnnotated {
+				t.Errorf("Expected %s: %v, got %v", remediate.AnnotationLastRestart, tt.expectAnnotated, deployment.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestRemediationReconciler_RollbackImageRecordsRevision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	isController := true
+	template := func(image string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+		}
+	}
+	replicaSet := func(name, revision, image string, created time.Time) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "api"},
+				Annotations:       map[string]string{"deployment.kubernetes.io/revision": revision},
+				CreationTimestamp: metav1.NewTime(created),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: types.UID("uid-api"), Controller: &isController,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: template(image)},
+		}
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: types.UID("uid-api")},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: template("api:v2"),
+		},
+	}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-rollback", Namespace: "default"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert:    k8shealerv1alpha1.AlertInfo{Name: "KubePodCrashLooping"},
+			Target:   k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default"},
+			Action:   k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeRollbackImage},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+	}
+
+	now := time.Now()
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, remediation,
+			replicaSet("api-1", "1", "api:v1", now.Add(-2*time.Hour)),
+			replicaSet("api-2", "2", "api:v2", now.Add(-time.Minute))).
+		WithStatusSubresource(remediation).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-rollback", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Fatalf("Expected phase Succeeded, got %s (%s)", updated.Status.Phase, updated.Status.Reason)
+	}
+	rollback := updated.Status.Rollback
+	if rollback == nil || rollback.Revision != 1 || rollback.Source != "ReplicaSet api-1" || rollback.Images["app"] != "api:v1" {
+		t.Errorf("Expected rollback to revision 1 recorded in status, got %+v", rollback)
+	}
+
+	rolledBack := &appsv1.Deployment{}
+	if err := client.Get(ctx, types.NamespacedName{Name: "api", Namespace: "default"}, rolledBack); err != nil {
+		t.Fatalf("Failed to get deployment: %v", err)
+	}
+	if got := rolledBack.Spec.Template.Spec.Containers[0].Image; got != "api:v1" {
+		t.Errorf("Expected image api:v1, got %s", got)
+	}
+}
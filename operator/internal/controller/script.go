@@ -0,0 +1,255 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/dashboard"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const (
+	// scriptJobPollInterval is how often a running CustomScript Job is checked
+	// in case its status change is missed
+	scriptJobPollInterval = 15 * time.Second
+
+	// scriptLogTailLines is how many log lines of a finished Job are kept
+	scriptLogTailLines = 20
+
+	// maxJobLogTail caps status.jobLogTail in bytes; the end is kept
+	maxJobLogTail = 1024
+)
+
+// PodLogs reads the logs of a pod
+type PodLogs interface {
+	// TailLogs returns the last lines of the logs of the pod's first container
+	TailLogs(ctx context.Context, namespace, pod string, lines int64) (string, error)
+}
+
+// clientsetPodLogs reads pod logs through the API server
+type clientsetPodLogs struct {
+	clientset kubernetes.Interface
+}
+
+// NewPodLogs returns a PodLogs backed by clientset
+func NewPodLogs(clientset kubernetes.Interface) PodLogs {
+	return &clientsetPodLogs{clientset: clientset}
+}
+
+func (c *clientsetPodLogs) TailLogs(ctx context.Context, namespace, pod string, lines int64) (string, error) {
+	stream, err := c.clientset.CoreV1().Pods(namespace).GetLogs(pod, &corev1.PodLogOptions{TailLines: &lines}).Stream(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logs of pod %s: %w", pod, err)
+	}
+	defer stream.Close()
+
+	logs, err := io.ReadAll(io.LimitReader(stream, 64*1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs of pod %s: %w", pod, err)
+	}
+	return string(logs), nil
+}
+
+// startScriptJob creates the Job for a CustomScript remediation from its
+// RemediationJobTemplate or ConfigMap and moves the remediation to Applying.
+// The Job runs in the Remediation's namespace and is owned by it.
+func (r *RemediationReconciler) startScriptJob(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+	script, err := remediate.LoadScriptJob(ctx, reader, remediation.Namespace, remediation.Spec.Action.Params)
+	if err != nil {
+		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to load script: %v", err))
+	}
+	job, err := remediate.BuildScriptJob(remediation, script)
+	if err != nil {
+		return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to build script Job: %v", err))
+	}
+	if err := controllerutil.SetControllerReference(remediation, job, r.Scheme); err != nil {
+		logger.Error(err, "Failed to set owner reference on script Job")
+		return ctrl.Result{}, err
+	}
+
+	if err := r.Create(ctx, job); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), err.Error())
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Failed to create script Job: %v", err))
+		}
+		// Created by an earlier reconcile whose status update was lost, unless
+		// the name is taken by a Job that is not ours
+		existing := &batchv1.Job{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(job), existing); err != nil {
+			logger.Error(err, "Failed to get existing script Job", "job", job.Name)
+			return ctrl.Result{}, err
+		}
+		if !metav1.IsControlledBy(existing, remediation) || existing.Labels[remediate.LabelRemediation] != remediation.Name {
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Job %s already exists and does not belong to this remediation", job.Name))
+		}
+		logger.Info("Script Job already exists", "job", job.Name)
+	} else {
+		logger.Info("Created script Job", "job", job.Name, "source", script.Source)
+	}
+
+	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseApplying
+	remediation.Status.Reason = fmt.Sprintf("Running Job %s from %s", job.Name, script.Source)
+	remediation.Status.JobName = job.Name
+	now := metav1.Now()
+	remediation.Status.LastUpdateTime = &now
+	remediation.Status.Attempts++
+
+	// Keep the alert quiet while the Job runs; it is lifted if the Job fails
+	// or the silence cannot be recorded
+	silenced := r.silenceAlert(ctx, remediation)
+
+	if err := r.Status().Update(ctx, remediation); err != nil {
+		logger.Error(err, "Failed to update status to Applying")
+		if silenced {
+			r.expireSilence(ctx, remediation)
+		}
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: scriptJobPollInterval}, nil
+}
+
+// trackScriptJob moves a CustomScript remediation to Succeeded or Failed
+// once its Job finishes, keeping the end of the Job's logs in status
+func (r *RemediationReconciler) trackScriptJob(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	jobName := remediation.Status.JobName
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: remediation.Namespace, Name: jobName}, job); err != nil {
+		if apierrors.IsNotFound(err) {
+			return r.updateStatusToFailed(ctx, remediation, fmt.Sprintf("Script Job %s was deleted before it finished", jobName))
+		}
+		logger.Error(err, "Failed to get script Job", "job", jobName)
+		return ctrl.Result{}, err
+	}
+
+	finished := jobFinishedCondition(job)
+	if finished == nil {
+		return ctrl.Result{RequeueAfter: scriptJobPollInterval}, nil
+	}
+
+	remediation.Status.JobLogTail = r.jobLogTail(ctx, job)
+
+	if finished.Type == batchv1.JobFailed {
+		reason := fmt.Sprintf("Script Job %s failed: %s", jobName, finished.Message)
+		dashboard.RecordRemediationFailed(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), reason)
+		return r.updateStatusToFailed(ctx, remediation, reason)
+	}
+
+	dashboard.RecordRemediationApplied(remediation.Name, remediation.Spec.Target.Kind, remediation.Spec.Target.Name, remediation.Spec.Target.Namespace, string(remediation.Spec.Action.Type), fmt.Sprintf("job %s completed", jobName))
+
+	remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseSucceeded
+	remediation.Status.Reason = fmt.Sprintf("Script Job %s completed", jobName)
+	applied := metav1.Now()
+	remediation.Status.AppliedAt = &applied
+	remediation.Status.ResolvedAt = &applied
+	remediation.Status.LastUpdateTime = &applied
+
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               "Applied",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: applied,
+		Reason:             "ScriptJobCompleted",
+		Message:            fmt.Sprintf("Job %s completed", jobName),
+	})
+
+	if err := r.Status().Update(ctx, remediation); err != nil {
+		logger.Error(err, "Failed to update status to Succeeded")
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("Script Job completed", "job", jobName)
+	return ctrl.Result{}, nil
+}
+
+// jobFinishedCondition returns the Complete or Failed condition of a
+// finished Job; nil while it is still running
+func jobFinishedCondition(job *batchv1.Job) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		c := &job.Status.Conditions[i]
+		if (c.Type == batchv1.JobComplete || c.Type == batchv1.JobFailed) && c.Status == corev1.ConditionTrue {
+			return c
+		}
+	}
+	return nil
+}
+
+// jobLogTail returns the end of the logs of the Job's newest pod. Logs are
+// informational, so failures are logged and an empty string is returned.
+func (r *RemediationReconciler) jobLogTail(ctx context.Context, job *batchv1.Job) string {
+	if r.PodLogs == nil {
+		return ""
+	}
+	logger := log.FromContext(ctx)
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(job.Namespace), client.MatchingLabels{"job-name": job.Name}); err != nil {
+		logger.Error(err, "Failed to list script Job pods", "job", job.Name)
+		return ""
+	}
+	var newest *corev1.Pod
+	for i := range pods.Items {
+		if newest == nil || newest.CreationTimestamp.Before(&pods.Items[i].CreationTimestamp) {
+			newest = &pods.Items[i]
+		}
+	}
+	if newest == nil {
+		return ""
+	}
+
+	logs, err := r.PodLogs.TailLogs(ctx, newest.Namespace, newest.Name, scriptLogTailLines)
+	if err != nil {
+		logger.Error(err, "Failed to read script Job logs", "job", job.Name, "pod", newest.Name)
+		return ""
+	}
+	logs = strings.TrimSpace(logs)
+	if len(logs) > maxJobLogTail {
+		start := len(logs) - maxJobLogTail + len("...")
+		// Do not start in the middle of a multi-byte character
+		for start < len(logs) && !utf8.RuneStart(logs[start]) {
+			start++
+		}
+		logs = "..." + logs[start:]
+	}
+	return logs
+}
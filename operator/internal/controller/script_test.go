@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// fakePodLogs returns fixed logs and records which pod was read
+type fakePodLogs struct {
+	logs string
+	pod  string
+}
+
+func (f *fakePodLogs) TailLogs(_ context.Context, _, pod string, _ int64) (string, error) {
+	f.pod = pod
+	return f.logs, nil
+}
+
+func TestRemediationReconciler_CustomScript(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		mode           k8shealerv1alpha1.StrategyMode
+		params         map[string]string
+		jobCondition   batchv1.JobConditionType
+		deleteJob      bool
+		existingJob    types.UID // owner UID of a Job already holding the name; "-" for no owner
+		expectStarted  bool
+		expectPhase    k8shealerv1alpha1.RemediationPhase
+		expectLogTail  string
+		expectInReason string
+	}{
+		{
+			name:          "job completes",
+			mode:          k8shealerv1alpha1.StrategyModeDirect,
+			params:        map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			jobCondition:  batchv1.JobComplete,
+			expectStarted: true,
+			expectPhase:   k8shealerv1alpha1.RemediationPhaseSucceeded,
+			expectLogTail: "flushed 42 keys",
+		},
+		{
+			name:           "job fails",
+			mode:           k8shealerv1alpha1.StrategyModeDirect,
+			params:         map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			jobCondition:   batchv1.JobFailed,
+			expectStarted:  true,
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectLogTail:  "flushed 42 keys",
+			expectInReason: "BackoffLimitExceeded",
+		},
+		{
+			name:           "job deleted while running",
+			mode:           k8shealerv1alpha1.StrategyModeDirect,
+			params:         map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			deleteJob:      true,
+			expectStarted:  true,
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectInReason: "deleted",
+		},
+		{
+			name:          "adopts its own job from a lost status update",
+			mode:          k8shealerv1alpha1.StrategyModeDirect,
+			params:        map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			jobCondition:  batchv1.JobComplete,
+			existingJob:   "rem-uid",
+			expectStarted: true,
+			expectPhase:   k8shealerv1alpha1.RemediationPhaseSucceeded,
+			expectLogTail: "flushed 42 keys",
+		},
+		{
+			name:           "job name taken by another owner",
+			mode:           k8shealerv1alpha1.StrategyModeDirect,
+			params:         map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			existingJob:    "other-uid",
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectInReason: "does not belong",
+		},
+		{
+			name:           "job name taken by an unowned job",
+			mode:           k8shealerv1alpha1.StrategyModeDirect,
+			params:         map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			existingJob:    "-",
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectInReason: "does not belong",
+		},
+		{
+			name:           "missing template",
+			mode:           k8shealerv1alpha1.StrategyModeDirect,
+			params:         map[string]string{remediate.ParamJobTemplate: "missing"},
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectInReason: "missing",
+		},
+		{
+			name:           "not in GitOps mode",
+			mode:           k8shealerv1alpha1.StrategyModeGitOps,
+			params:         map[string]string{remediate.ParamJobTemplate: "flush-cache"},
+			expectPhase:    k8shealerv1alpha1.RemediationPhaseFailed,
+			expectInReason: "only supported in Direct mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &k8shealerv1alpha1.RemediationJobTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "flush-cache", Namespace: "ops"},
+				Spec: k8shealerv1alpha1.RemediationJobTemplateSpec{
+					ServiceAccountName: "cache-flusher",
+					JobTemplate: batchv1.JobTemplateSpec{
+						Spec: batchv1.JobSpec{
+							Template: corev1.PodTemplateSpec{
+								Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "flush", Image: "redis:7"}}},
+							},
+						},
+					},
+				},
+			}
+			remediation := &k8shealerv1alpha1.Remediation{
+				ObjectMeta: metav1.ObjectMeta{Name: "rem-script", Namespace: "ops", UID: types.UID("rem-uid")},
+				Spec: k8shealerv1alpha1.RemediationSpec{
+					Alert: k8shealerv1alpha1.AlertInfo{
+						Name:    "RedisMemoryHigh",
+						Source:  "alertmanager",
+						Payload: `{"labels":{"alertname":"RedisMemoryHigh","namespace":"cache"}}`,
+					},
+					Target:   k8shealerv1alpha1.TargetResource{Kind: "StatefulSet", Name: "redis", Namespace: "cache"},
+					Action:   k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeCustomScript, Params: tt.params},
+					Strategy: k8shealerv1alpha1.Strategy{Mode: tt.mode},
+				},
+				Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+			}
+
+			builder := fakeclient.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(template, remediation).
+				WithStatusSubresource(remediation)
+			if tt.existingJob != "" {
+				existing := &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:      remediate.ScriptJobName("rem-script"),
+					Namespace: "ops",
+					Labels:    map[string]string{remediate.LabelRemediation: "rem-script"},
+				}}
+				existing.Spec.Template.Spec.ServiceAccountName = "cache-flusher"
+				if tt.existingJob != "-" {
+					controller := true
+					existing.OwnerReferences = []metav1.OwnerReference{{
+						APIVersion: k8shealerv1alpha1.GroupVersion.String(),
+						Kind:       "Remediation",
+						Name:       "rem-script",
+						UID:        tt.existingJob,
+						Controller: &controller,
+					}}
+				}
+				builder = builder.WithObjects(existing).WithStatusSubresource(existing)
+			}
+			cl := builder.Build()
+			logs := &fakePodLogs{logs: "connecting\nflushed 42 keys\n"}
+			r := &RemediationReconciler{Client: cl, Scheme: scheme, PodLogs: logs}
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "rem-script", Namespace: "ops"}
+
+			if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+
+			updated := &k8shealerv1alpha1.Remediation{}
+			if err := cl.Get(ctx, key, updated); err != nil {
+				t.Fatalf("Failed to get remediation: %v", err)
+			}
+
+			if tt.expectStarted {
+				if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseApplying {
+					t.Fatalf("Expected phase Applying while the Job runs, got %s (%s)", updated.Status.Phase, updated.Status.Reason)
+				}
+
+				job := &batchv1.Job{}
+				jobKey := client.ObjectKey{Namespace: "ops", Name: updated.Status.JobName}
+				if err := cl.Get(ctx, jobKey, job); err != nil {
+					t.Fatalf("Expected script Job %s: %v", updated.Status.JobName, err)
+				}
+				if len(job.OwnerReferences) != 1 || job.OwnerReferences[0].Name != "rem-script" {
+					t.Errorf("Expected the Job to be owned by the remediation, got %v", job.OwnerReferences)
+				}
+				if sa := job.Spec.Template.Spec.ServiceAccountName; sa != "cache-flusher" {
+					t.Errorf("Expected the Job to run as cache-flusher, got %q", sa)
+				}
+
+				// Still running: nothing changes
+				if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+					t.Fatalf("Reconcile failed: %v", err)
+				}
+
+				switch {
+				case tt.deleteJob:
+					if err := cl.Delete(ctx, job); err != nil {
+						t.Fatalf("Failed to delete Job: %v", err)
+					}
+				default:
+					pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+						Name:      job.Name + "-abcde",
+						Namespace: "ops",
+						Labels:    map[string]string{"job-name": job.Name},
+					}}
+					if err := cl.Create(ctx, pod); err != nil {
+						t.Fatalf("Failed to create pod: %v", err)
+					}
+					job.Status.Conditions = []batchv1.JobCondition{{
+						Type:    tt.jobCondition,
+						Status:  corev1.ConditionTrue,
+						Reason:  "BackoffLimitExceeded",
+						Message: "Job has reached the specified backoff limit (BackoffLimitExceeded)",
+					}}
+					if err := cl.Status().Update(ctx, job); err != nil {
+						t.Fatalf("Failed to update Job status: %v", err)
+					}
+				}
+
+				if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+					t.Fatalf("Reconcile failed: %v", err)
+				}
+				if err := cl.Get(ctx, key, updated); err != nil {
+					t.Fatalf("Failed to get remediation: %v", err)
+				}
+				if tt.expectLogTail != "" && logs.pod != job.Name+"-abcde" {
+					t.Errorf("Expected logs of the Job's pod, read %q", logs.pod)
+				}
+			}
+
+			if updated.Status.Phase != tt.expectPhase {
+				t.Errorf("Expected phase %s, got %s (%s)", tt.expectPhase, updated.Status.Phase, updated.Status.Reason)
+			}
+			if tt.expectLogTail != "" && !strings.Contains(updated.Status.JobLogTail, tt.expectLogTail) {
+				t.Errorf("Expected log tail to contain %q, got %q", tt.expectLogTail, updated.Status.JobLogTail)
+			}
+			if !strings.Contains(updated.Status.Reason, tt.expectInReason) {
+				t.Errorf("Expected reason to contain %q, got %q", tt.expectInReason, updated.Status.Reason)
+			}
+		})
+	}
+}
+
+func TestRemediationReconciler_JobLogTailIsBounded(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	older := metav1.NewTime(time.Now().Add(-time.Minute))
+	newer := metav1.Now()
+	pods := []client.Object{
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job-old", Namespace: "ops", Labels: map[string]string{"job-name": "job"}, CreationTimestamp: older}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job-new", Namespace: "ops", Labels: map[string]string{"job-name": "job"}, CreationTimestamp: newer}},
+	}
+	cl := fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(pods...).Build()
+	logs := &fakePodLogs{logs: strings.Repeat("é", 2*maxJobLogTail) + "done"}
+	r := &RemediationReconciler{Client: cl, Scheme: scheme, PodLogs: logs}
+
+	tail := r.jobLogTail(context.Background(), &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "job", Namespace: "ops"}})
+	if logs.pod != "job-new" {
+		t.Errorf("Expected logs of the newest pod, read %q", logs.pod)
+	}
+	if len(tail) > maxJobLogTail || !strings.HasPrefix(tail, "...") || !strings.HasSuffix(tail, "done") {
+		t.Errorf("Expected a tail of at most %d bytes keeping the end, got %d bytes", maxJobLogTail, len(tail))
+	}
+	if !strings.HasPrefix(strings.TrimPrefix(tail, "..."), "é") {
+		t.Errorf("Expected the tail to start on a character boundary")
+	}
+}
+
+func TestNewPodLogs(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "job-abcde", Namespace: "ops"}})
+
+	logs, err := NewPodLogs(clientset).TailLogs(context.Background(), "ops", "job-abcde", 20)
+	if err != nil {
+		t.Fatalf("TailLogs failed: %v", err)
+	}
+	if logs == "" {
+		t.Errorf("Expected logs from the clientset")
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// ConditionSilenced is True while an Alertmanager silence covers the alert
+// that triggered a Remediation
+const ConditionSilenced = "Silenced"
+
+// DefaultSilenceDuration is how long the alert is silenced after a direct
+// remediation while the change is verified
+const DefaultSilenceDuration = 15 * time.Minute
+
+// Silencer creates and expires alert silences
+type Silencer interface {
+	// CreateSilence silences alerts with exactly labels for duration and
+	// returns the silence ID
+	CreateSilence(ctx context.Context, labels map[string]string, duration time.Duration, comment string) (string, error)
+
+	// ExpireSilence ends a silence early
+	ExpireSilence(ctx context.Context, id string) error
+}
+
+// silenceAlert silences the alert behind remediation for the verification
+// period and records the silence in its status. It is best effort: failing to
+// silence never fails the remediation. The status is not written; it reports
+// whether a silence was created, which the caller must expire with
+// expireSilence if writing the status fails.
+func (r *RemediationReconciler) silenceAlert(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) bool {
+	if r.Silencer == nil || remediation.Status.SilenceID != "" {
+		return false
+	}
+	// Only Alertmanager can silence the alert; Grafana, generic and detector
+	// alerts never went through it
+	if remediation.Spec.Alert.Source != remediate.SourceAlertmanager {
+		return false
+	}
+	logger := log.FromContext(ctx)
+
+	labels, err := alertLabels(remediation)
+	if err != nil || len(labels) == 0 {
+		logger.Info("Not silencing alert without labels", "error", err)
+		return false
+	}
+
+	duration := r.SilenceDuration
+	if duration <= 0 {
+		duration = DefaultSilenceDuration
+	}
+	comment := fmt.Sprintf("Remediation %s/%s is applying %s to %s %s/%s; silenced while the change is verified",
+		remediation.Namespace, remediation.Name, remediation.Spec.Action.Type,
+		remediation.Spec.Target.Kind, remediation.Spec.Target.Namespace, remediation.Spec.Target.Name)
+
+	id, err := r.Silencer.CreateSilence(ctx, labels, duration, comment)
+	if err != nil {
+		logger.Error(err, "Failed to create Alertmanager silence")
+		return false
+	}
+	logger.Info("Silenced alert while the remediation is verified", "silenceID", id, "duration", duration)
+
+	remediation.Status.SilenceID = id
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               ConditionSilenced,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "SilenceCreated",
+		Message:            fmt.Sprintf("Alert silenced for %s by silence %s", duration, id),
+	})
+	return true
+}
+
+// expireSilence lifts the silence created for remediation so the alert can
+// fire again. The status is not written.
+func (r *RemediationReconciler) expireSilence(ctx context.Context, remediation *k8shealerv1alpha1.Remediation) {
+	if r.Silencer == nil || remediation.Status.SilenceID == "" ||
+		!meta.IsStatusConditionTrue(remediation.Status.Conditions, ConditionSilenced) {
+		return
+	}
+	logger := log.FromContext(ctx)
+
+	id := remediation.Status.SilenceID
+	if err := r.Silencer.ExpireSilence(ctx, id); err != nil {
+		logger.Error(err, "Failed to expire Alertmanager silence", "silenceID", id)
+		return
+	}
+	logger.Info("Expired Alertmanager silence", "silenceID", id)
+
+	meta.SetStatusCondition(&remediation.Status.Conditions, metav1.Condition{
+		Type:               ConditionSilenced,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: remediation.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             "SilenceExpired",
+		Message:            fmt.Sprintf("Silence %s expired because the remediation failed", id),
+	})
+}
+
+// alertLabels returns the labels of the alert recorded in remediation's payload
+func alertLabels(remediation *k8shealerv1alpha1.Remediation) (map[string]string, error) {
+	labels, _, err := remediate.DecodeAlertPayload(remediation.Spec.Alert.Payload)
+	return labels, err
+}
@@ -0,0 +1,404 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/alertmanager"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// fakeAlertmanager is a local stand-in for the Alertmanager v2 silences API
+type fakeAlertmanager struct {
+	mu       sync.Mutex
+	created  []alertmanager.Silence
+	expired  []string
+	failNext bool
+}
+
+func (f *fakeAlertmanager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failNext {
+		f.failNext = false
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var silence alertmanager.Silence
+		_ = json.NewDecoder(r.Body).Decode(&silence)
+		f.created = append(f.created, silence)
+		_, _ = w.Write([]byte(`{"silenceID":"silence-1"}`))
+	case r.Method == http.MethodDelete && r.URL.Path == "/api/v2/silence/silence-1":
+		f.expired = append(f.expired, "silence-1")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestRemediationReconciler_SilencesAlert(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+			},
+		},
+	}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-silence", Namespace: "default"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:    "HighLatency",
+				Source:  "alertmanager",
+				Payload: `{"labels":{"alertname":"HighLatency","namespace":"default","deployment":"api","pod":""}}`,
+			},
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default"},
+			Action: k8shealerv1alpha1.Action{
+				Type:   k8shealerv1alpha1.ActionTypeScaleUp,
+				Params: map[string]string{"scaleUpPercent": "50", "maxReplicas": "10"},
+			},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+	}
+
+	am := &fakeAlertmanager{}
+	server := httptest.NewServer(am)
+	defer server.Close()
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, remediation).
+		WithStatusSubresource(remediation).
+		Build()
+	r := &RemediationReconciler{
+		Client:          client,
+		Scheme:          scheme,
+		Silencer:        alertmanager.NewClient(server.URL),
+		SilenceDuration: 10 * time.Minute,
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-silence", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Fatalf("Expected phase Succeeded, got %s", updated.Status.Phase)
+	}
+	if updated.Status.SilenceID != "silence-1" {
+		t.Errorf("Expected silence ID in status, got %q", updated.Status.SilenceID)
+	}
+	if !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionSilenced) {
+		t.Errorf("Expected %s condition to be True", ConditionSilenced)
+	}
+
+	if len(am.created) != 1 {
+		t.Fatalf("Expected 1 silence, got %d", len(am.created))
+	}
+	silence := am.created[0]
+	if len(silence.Matchers) != 3 {
+		t.Errorf("Expected matchers for the 3 non-empty alert labels, got %+v", silence.Matchers)
+	}
+	if got := silence.EndsAt.Sub(silence.StartsAt); got != 10*time.Minute {
+		t.Errorf("Expected a 10m silence, got %s", got)
+	}
+
+	// A failure afterwards lifts the silence so the alert can page again
+	if _, err := r.updateStatusToFailed(ctx, updated, "Change did not help"); err != nil {
+		t.Fatalf("updateStatusToFailed failed: %v", err)
+	}
+	if len(am.expired) != 1 {
+		t.Errorf("Expected the silence to be expired, got %v", am.expired)
+	}
+	if meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionSilenced) {
+		t.Errorf("Expected %s condition to be False after expiry", ConditionSilenced)
+	}
+}
+
+func TestRemediationReconciler_ExpiresSilenceWhenStatusIsNotRecorded(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr(int32(2)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx"}}},
+			},
+		},
+	}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-silence", Namespace: "default"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:    "HighLatency",
+				Source:  "alertmanager",
+				Payload: `{"labels":{"alertname":"HighLatency","namespace":"default","deployment":"api"}}`,
+			},
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default"},
+			Action: k8shealerv1alpha1.Action{
+				Type:   k8shealerv1alpha1.ActionTypeScaleUp,
+				Params: map[string]string{"scaleUpPercent": "50", "maxReplicas": "10"},
+			},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+	}
+
+	am := &fakeAlertmanager{}
+	server := httptest.NewServer(am)
+	defer server.Close()
+
+	// The status update recording the silence conflicts
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, remediation).
+		WithStatusSubresource(remediation).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c ctrlclient.Client, subResource string, obj ctrlclient.Object, opts ...ctrlclient.SubResourceUpdateOption) error {
+				return apierrors.NewConflict(k8shealerv1alpha1.GroupVersion.WithResource("remediations").GroupResource(), obj.GetName(), fmt.Errorf("object was modified"))
+			},
+		}).
+		Build()
+	r := &RemediationReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		Silencer: alertmanager.NewClient(server.URL),
+	}
+	key := types.NamespacedName{Name: "rem-silence", Namespace: "default"}
+
+	if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: key}); err == nil {
+		t.Fatal("Expected the failed status update to be returned")
+	}
+	if len(am.created) != 1 {
+		t.Fatalf("Expected 1 silence, got %d", len(am.created))
+	}
+	if len(am.expired) != 1 || am.expired[0] != "silence-1" {
+		t.Errorf("Expected the unrecorded silence to be expired, got %v", am.expired)
+	}
+}
+
+func TestRemediationReconciler_SilenceFailureDoesNotFailRemediation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr(int32(2))},
+	}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-silence", Namespace: "default"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:    "HighLatency",
+				Source:  "alertmanager",
+				Payload: `{"labels":{"alertname":"HighLatency"}}`,
+			},
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "default"},
+			Action: k8shealerv1alpha1.Action{
+				Type:   k8shealerv1alpha1.ActionTypeScaleUp,
+				Params: map[string]string{"scaleUpPercent": "50", "maxReplicas": "10"},
+			},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+	}
+
+	am := &fakeAlertmanager{failNext: true}
+	server := httptest.NewServer(am)
+	defer server.Close()
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(deployment, remediation).
+		WithStatusSubresource(remediation).
+		Build()
+	r := &RemediationReconciler{Client: client, Scheme: scheme, Silencer: alertmanager.NewClient(server.URL)}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-silence", Namespace: "default"}
+
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Errorf("Expected phase Succeeded, got %s", updated.Status.Phase)
+	}
+	if updated.Status.SilenceID != "" {
+		t.Errorf("Expected no silence ID, got %q", updated.Status.SilenceID)
+	}
+}
+
+func TestSilenceAlert_OnlyAlertmanagerAlerts(t *testing.T) {
+	tests := []struct {
+		source        string
+		expectSilence bool
+	}{
+		{source: "alertmanager", expectSilence: true},
+		{source: "grafana"},
+		{source: "generic"},
+		{source: "detector"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			am := &fakeAlertmanager{}
+			server := httptest.NewServer(am)
+			defer server.Close()
+
+			r := &RemediationReconciler{Silencer: alertmanager.NewClient(server.URL)}
+			remediation := &k8shealerv1alpha1.Remediation{
+				ObjectMeta: metav1.ObjectMeta{Name: "rem-silence", Namespace: "default"},
+				Spec: k8shealerv1alpha1.RemediationSpec{
+					Alert: k8shealerv1alpha1.AlertInfo{
+						Name:    "HighLatency",
+						Source:  tt.source,
+						Payload: `{"labels":{"alertname":"HighLatency","namespace":"default"}}`,
+					},
+				},
+			}
+			r.silenceAlert(context.Background(), remediation)
+
+			if silenced := len(am.created) == 1; silenced != tt.expectSilence {
+				t.Errorf("Expected silenced=%v, got %d silences", tt.expectSilence, len(am.created))
+			}
+			if (remediation.Status.SilenceID != "") != tt.expectSilence {
+				t.Errorf("Expected silence ID recorded=%v, got %q", tt.expectSilence, remediation.Status.SilenceID)
+			}
+		})
+	}
+}
+
+func TestRemediationReconciler_ExpiresSilenceWhenScriptJobFails(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	template := &k8shealerv1alpha1.RemediationJobTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "flush-cache", Namespace: "ops"},
+		Spec: k8shealerv1alpha1.RemediationJobTemplateSpec{
+			ServiceAccountName: "cache-flusher",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "flush", Image: "redis:7"}}},
+					},
+				},
+			},
+		},
+	}
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-script", Namespace: "ops", UID: types.UID("rem-uid")},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:    "RedisMemoryHigh",
+				Source:  "alertmanager",
+				Payload: `{"labels":{"alertname":"RedisMemoryHigh","namespace":"cache"}}`,
+			},
+			Target:   k8shealerv1alpha1.TargetResource{Kind: "StatefulSet", Name: "redis", Namespace: "cache"},
+			Action:   k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeCustomScript, Params: map[string]string{remediate.ParamJobTemplate: "flush-cache"}},
+			Strategy: k8shealerv1alpha1.Strategy{Mode: k8shealerv1alpha1.StrategyModeDirect},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{Phase: k8shealerv1alpha1.RemediationPhaseAnalyzing},
+	}
+
+	am := &fakeAlertmanager{}
+	server := httptest.NewServer(am)
+	defer server.Close()
+
+	client := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(template, remediation).
+		WithStatusSubresource(remediation).
+		Build()
+	r := &RemediationReconciler{
+		Client:   client,
+		Scheme:   scheme,
+		PodLogs:  &fakePodLogs{},
+		Silencer: alertmanager.NewClient(server.URL),
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "rem-script", Namespace: "ops"}
+
+	// The alert is silenced as soon as the Job starts
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	updated := &k8shealerv1alpha1.Remediation{}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseApplying {
+		t.Fatalf("Expected phase Applying, got %s (%s)", updated.Status.Phase, updated.Status.Reason)
+	}
+	if updated.Status.SilenceID != "silence-1" || !meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionSilenced) {
+		t.Fatalf("Expected the alert silenced while the Job runs, got %q and %+v", updated.Status.SilenceID, updated.Status.Conditions)
+	}
+
+	// The Job failing lifts the silence so the alert can page again
+	job := &batchv1.Job{}
+	if err := client.Get(ctx, types.NamespacedName{Name: updated.Status.JobName, Namespace: "ops"}, job); err != nil {
+		t.Fatalf("Failed to get Job: %v", err)
+	}
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}}
+	if err := client.Status().Update(ctx, job); err != nil {
+		t.Fatalf("Failed to update Job status: %v", err)
+	}
+	if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := client.Get(ctx, key, updated); err != nil {
+		t.Fatalf("Failed to get remediation: %v", err)
+	}
+	if updated.Status.Phase != k8shealerv1alpha1.RemediationPhaseFailed {
+		t.Fatalf("Expected phase Failed, got %s", updated.Status.Phase)
+	}
+	if len(am.expired) != 1 {
+		t.Errorf("Expected the silence to be expired, got %v", am.expired)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, ConditionSilenced)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "SilenceExpired" {
+		t.Errorf("Expected a false %s condition after expiry, got %+v", ConditionSilenced, condition)
+	}
+}
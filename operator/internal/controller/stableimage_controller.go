@@ -0,0 +1,358 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// DefaultStableImageSoakTime is how long a completed rollout must stay healthy
+// before its images are recorded as stable
+const DefaultStableImageSoakTime = 10 * time.Minute
+
+// StableImageKinds are the workload kinds whose stable images are tracked
+var StableImageKinds = []string{"Deployment", "StatefulSet", "DaemonSet"}
+
+// ScopeSource provides the namespace scope currently in effect
+type ScopeSource interface {
+	Scope() remediate.Scope
+}
+
+// StableImageReconciler records the images of one kind of workload as stable
+// once a rollout has completed and stayed healthy for the soak time, so that
+// RollbackImage has images to return to. Only workloads opted in with
+// heal8s.io/enabled=true, on themselves or their namespace, are tracked.
+type StableImageReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// Kind is the workload kind reconciled, one of StableImageKinds
+	Kind string
+
+	// Scope, if set, limits tracking to the operator's namespace scope
+	Scope ScopeSource
+
+	// SoakTime is how long a rollout must stay healthy; DefaultStableImageSoakTime if zero
+	SoakTime time.Duration
+
+	// HistoryLimit is how many stable image sets are kept on each workload;
+	// remediate.DefaultStableImagesHistory if zero
+	HistoryLimit int
+
+	now func() time.Time
+
+	mu sync.Mutex
+	// healthy holds the rollouts being soaked, by namespace/name
+	healthy map[string]healthyRollout
+}
+
+// healthyRollout is a completed rollout waiting out the soak time
+type healthyRollout struct {
+	images   remediate.ImageSet
+	since    time.Time
+	restarts int32
+}
+
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets;daemonsets,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// Reconcile tracks how long the workload's current rollout has been healthy
+// and records its images as stable once the soak time has passed
+func (r *StableImageReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	key := req.String()
+
+	obj, err := remediate.NewTargetObject(r.Kind)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		r.forget(key)
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get workload", "kind", r.Kind)
+		return ctrl.Result{}, err
+	}
+	if !obj.GetDeletionTimestamp().IsZero() {
+		r.forget(key)
+		return ctrl.Result{}, nil
+	}
+
+	optedIn, err := r.optedIn(ctx, obj)
+	if err != nil {
+		logger.Error(err, "Failed to check opt-in", "kind", r.Kind)
+		return ctrl.Result{}, err
+	}
+	if !optedIn {
+		r.forget(key)
+		return ctrl.Result{}, nil
+	}
+
+	template, selector, complete := rolloutState(obj)
+	images := remediate.TemplateImages(template)
+	if stable, err := remediate.StableImages(obj, template, ""); err == nil && stable != nil && stable.Equal(images) {
+		r.forget(key)
+		return ctrl.Result{}, nil
+	}
+	if !complete {
+		r.forget(key)
+		return ctrl.Result{}, nil
+	}
+
+	restarts, err := r.podRestarts(ctx, obj.GetNamespace(), selector)
+	if err != nil {
+		logger.Error(err, "Failed to count pod restarts", "kind", r.Kind)
+		return ctrl.Result{}, err
+	}
+
+	now := r.clock()
+	rollout := r.observe(key, images, restarts, now)
+	if remaining := r.soakTime() - now.Sub(rollout.since); remaining > 0 {
+		// Restarts do not change the workload's status, so look again when the soak is over
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	recorded, err := remediate.RecordStableImages(obj, template, r.historyLimit())
+	if err != nil {
+		logger.Error(err, "Failed to record stable images", "kind", r.Kind)
+		return ctrl.Result{}, err
+	}
+	if recorded {
+		if err := r.Patch(ctx, obj, patch); err != nil {
+			logger.Error(err, "Failed to record stable images", "kind", r.Kind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Recorded stable images", "kind", r.Kind, "containers", images.Containers, "initContainers", images.InitContainers)
+	}
+	r.forget(key)
+	return ctrl.Result{}, nil
+}
+
+// observe returns the healthy rollout tracked for key, starting the soak over
+// if the images changed or a pod restarted since it was last seen
+func (r *StableImageReconciler) observe(key string, images remediate.ImageSet, restarts int32, now time.Time) healthyRollout {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.healthy == nil {
+		r.healthy = make(map[string]healthyRollout)
+	}
+	rollout, ok := r.healthy[key]
+	if !ok || !rollout.images.Equal(images) || restarts > rollout.restarts {
+		rollout = healthyRollout{images: images, since: now}
+	}
+	// Fewer restarts means restarted pods were replaced; count from there
+	rollout.restarts = restarts
+	r.healthy[key] = rollout
+	return rollout
+}
+
+func (r *StableImageReconciler) forget(key string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.healthy, key)
+}
+
+// optedIn reports whether obj is in the operator's namespace scope and
+// annotated heal8s.io/enabled=true, on itself or its namespace. Tracking only
+// serves RollbackImage, so a heal8s.io/actions annotation must allow it.
+func (r *StableImageReconciler) optedIn(ctx context.Context, obj client.Object) (bool, error) {
+	var scope remediate.Scope
+	if r.Scope != nil {
+		scope = r.Scope.Scope()
+	}
+	if !scope.AllowsNamespace(obj.GetNamespace()) {
+		return false, nil
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: obj.GetNamespace()}, namespace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to get namespace %s: %w", obj.GetNamespace(), err)
+		}
+	}
+
+	scope.RequireOptIn = true
+	decision, err := scope.EvaluateOptIn(namespace.Annotations, obj.GetAnnotations(), k8shealerv1alpha1.ActionTypeRollbackImage)
+	if err != nil {
+		// A malformed annotation opts out, as it does for remediation
+		return false, nil
+	}
+	return decision.Allowed, nil
+}
+
+// rolloutState returns a workload's pod template and selector, and whether
+// its latest rollout has completed with every replica updated and available
+func rolloutState(obj client.Object) (*corev1.PodTemplateSpec, *metav1.LabelSelector, bool) {
+	switch v := obj.(type) {
+	case *appsv1.Deployment:
+		desired := int32(1)
+		if v.Spec.Replicas != nil {
+			desired = *v.Spec.Replicas
+		}
+		s := v.Status
+		complete := s.ObservedGeneration >= v.Generation && desired > 0 &&
+			s.Replicas == desired && s.UpdatedReplicas == desired &&
+			s.AvailableReplicas == desired && s.UnavailableReplicas == 0
+		return &v.Spec.Template, v.Spec.Selector, complete
+	case *appsv1.StatefulSet:
+		desired := int32(1)
+		if v.Spec.Replicas != nil {
+			desired = *v.Spec.Replicas
+		}
+		s := v.Status
+		complete := s.ObservedGeneration >= v.Generation && desired > 0 &&
+			s.Replicas == desired && s.UpdatedReplicas == desired &&
+			s.ReadyReplicas == desired && s.AvailableReplicas == desired
+		return &v.Spec.Template, v.Spec.Selector, complete
+	case *appsv1.DaemonSet:
+		s := v.Status
+		complete := s.ObservedGeneration >= v.Generation && s.DesiredNumberScheduled > 0 &&
+			s.CurrentNumberScheduled == s.DesiredNumberScheduled &&
+			s.UpdatedNumberScheduled == s.DesiredNumberScheduled &&
+			s.NumberAvailable == s.DesiredNumberScheduled && s.NumberUnavailable == 0
+		return &v.Spec.Template, v.Spec.Selector, complete
+	default:
+		return &corev1.PodTemplateSpec{}, nil, false
+	}
+}
+
+// podRestarts returns the total container restarts of the pods selected by
+// selector that are not being deleted
+func (r *StableImageReconciler) podRestarts(ctx context.Context, namespace string, labelSelector *metav1.LabelSelector) (int32, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selector: %w", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, fmt.Errorf("failed to list pods: %w", err)
+	}
+
+	var restarts int32
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, status := range pod.Status.InitContainerStatuses {
+			restarts += status.RestartCount
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			restarts += status.RestartCount
+		}
+	}
+	return restarts, nil
+}
+
+// workloadsInNamespace enqueues every workload of the reconciled kind in a
+// namespace whose annotations changed, since that may opt them in or out
+func (r *StableImageReconciler) workloadsInNamespace(ctx context.Context, namespace client.Object) []reconcile.Request {
+	list, err := newWorkloadList(r.Kind)
+	if err != nil {
+		return nil
+	}
+	if err := r.List(ctx, list, client.InNamespace(namespace.GetName())); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list workloads", "kind", r.Kind, "namespace", namespace.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	_ = meta.EachListItem(list, func(item runtime.Object) error {
+		if obj, ok := item.(client.Object); ok {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)})
+		}
+		return nil
+	})
+	return requests
+}
+
+func newWorkloadList(kind string) (client.ObjectList, error) {
+	switch kind {
+	case "Deployment":
+		return &appsv1.DeploymentList{}, nil
+	case "StatefulSet":
+		return &appsv1.StatefulSetList{}, nil
+	case "DaemonSet":
+		return &appsv1.DaemonSetList{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported workload kind: %s", kind)
+	}
+}
+
+func (r *StableImageReconciler) soakTime() time.Duration {
+	if r.SoakTime > 0 {
+		return r.SoakTime
+	}
+	return DefaultStableImageSoakTime
+}
+
+func (r *StableImageReconciler) historyLimit() int {
+	if r.HistoryLimit > 0 {
+		return r.HistoryLimit
+	}
+	return remediate.DefaultStableImagesHistory
+}
+
+func (r *StableImageReconciler) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *StableImageReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	obj, err := remediate.NewTargetObject(r.Kind)
+	if err != nil {
+		return err
+	}
+	if _, err := newWorkloadList(r.Kind); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("stableimage-"+strings.ToLower(r.Kind)).
+		For(obj).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.workloadsInNamespace),
+			builder.WithPredicates(predicate.AnnotationChangedPredicate{})).
+		Complete(r)
+}
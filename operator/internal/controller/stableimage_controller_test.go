@@ -0,0 +1,240 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+	"github.com/heal8s/heal8s/operator/internal/webhooks"
+)
+
+func rolledOutDeployment(annotations map[string]string) *appsv1.Deployment {
+	replicas := int32(2)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop", Generation: 3, Annotations: annotations},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "api:v2"}}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			ReadyReplicas:      2,
+			AvailableReplicas:  2,
+		},
+	}
+}
+
+func apiPod(name string, restarts int32) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "shop", Labels: map[string]string{"app": "api"}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{Name: "app", RestartCount: restarts}}},
+	}
+}
+
+func TestStableImageReconciler_RecordsAfterSoak(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "shop",
+		Annotations: map[string]string{remediate.AnnotationEnabled: "true"},
+	}}
+	pod := apiPod("api-1", 0)
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(namespace, rolledOutDeployment(nil), pod, apiPod("api-2", 0)).
+		Build()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	r := &StableImageReconciler{Client: cl, Scheme: scheme, Kind: "Deployment", SoakTime: 10 * time.Minute, now: func() time.Time { return now }}
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "api", Namespace: "shop"}}
+	stableImages := func() string {
+		deployment := &appsv1.Deployment{}
+		if err := cl.Get(ctx, req.NamespacedName, deployment); err != nil {
+			t.Fatalf("Failed to get deployment: %v", err)
+		}
+		return deployment.Annotations[remediate.AnnotationStableImages]
+	}
+
+	result, err := r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != 10*time.Minute {
+		t.Errorf("Expected a requeue after the soak time, got %s", result.RequeueAfter)
+	}
+
+	// A restart during the soak starts it over
+	now = now.Add(8 * time.Minute)
+	pod.Status.ContainerStatuses[0].RestartCount = 1
+	if err := cl.Status().Update(ctx, pod); err != nil {
+		t.Fatalf("Failed to update pod: %v", err)
+	}
+	result, err = r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != 10*time.Minute || stableImages() != "" {
+		t.Errorf("Expected the soak to restart after a pod restart, got requeue %s and %q", result.RequeueAfter, stableImages())
+	}
+
+	now = now.Add(10 * time.Minute)
+	result, err = r.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Expected no requeue once recorded, got %s", result.RequeueAfter)
+	}
+	if got := stableImages(); got != `{"containers":{"app":"api:v2"}}` {
+		t.Errorf("Expected api:v2 recorded as stable, got %q", got)
+	}
+}
+
+func TestStableImageReconciler_Skips(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name                 string
+		namespaceAnnotations map[string]string
+		deployment           func() *appsv1.Deployment
+		scope                remediate.Scope
+	}{
+		{
+			name:       "namespace not opted in",
+			deployment: func() *appsv1.Deployment { return rolledOutDeployment(nil) },
+		},
+		{
+			name:                 "workload opted out",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true"},
+			deployment: func() *appsv1.Deployment {
+				return rolledOutDeployment(map[string]string{remediate.AnnotationEnabled: "false"})
+			},
+		},
+		{
+			name:                 "RollbackImage not allowed",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true", remediate.AnnotationActions: "ScaleUp"},
+			deployment:           func() *appsv1.Deployment { return rolledOutDeployment(nil) },
+		},
+		{
+			name:                 "namespace outside the operator's scope",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true"},
+			deployment:           func() *appsv1.Deployment { return rolledOutDeployment(nil) },
+			scope:                remediate.Scope{ExcludeNamespaces: []string{"shop"}},
+		},
+		{
+			name:                 "rollout in progress",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true"},
+			deployment: func() *appsv1.Deployment {
+				deployment := rolledOutDeployment(nil)
+				deployment.Status.UpdatedReplicas = 1
+				return deployment
+			},
+		},
+		{
+			name:                 "replica unavailable",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true"},
+			deployment: func() *appsv1.Deployment {
+				deployment := rolledOutDeployment(nil)
+				deployment.Status.AvailableReplicas = 1
+				deployment.Status.UnavailableReplicas = 1
+				return deployment
+			},
+		},
+		{
+			name:                 "images already stable",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "true"},
+			deployment: func() *appsv1.Deployment {
+				return rolledOutDeployment(map[string]string{remediate.AnnotationStableImages: `{"containers":{"app":"api:v2"}}`})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "shop", Annotations: tt.namespaceAnnotations}}
+			deployment := tt.deployment()
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, deployment, apiPod("api-1", 0)).Build()
+			r := &StableImageReconciler{Client: cl, Scheme: scheme, Kind: "Deployment", Scope: webhooks.StaticScope(tt.scope), SoakTime: time.Nanosecond}
+			ctx := context.Background()
+
+			result, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(deployment)})
+			if err != nil {
+				t.Fatalf("Reconcile failed: %v", err)
+			}
+			if result.RequeueAfter != 0 {
+				t.Errorf("Expected no requeue, got %s", result.RequeueAfter)
+			}
+
+			updated := &appsv1.Deployment{}
+			if err := cl.Get(ctx, client.ObjectKeyFromObject(deployment), updated); err != nil {
+				t.Fatalf("Failed to get deployment: %v", err)
+			}
+			if updated.Annotations[remediate.AnnotationStableImagesHistory] != "" {
+				t.Errorf("Expected no stable images recorded, got %v", updated.Annotations)
+			}
+		})
+	}
+}
+
+func TestRolloutState(t *testing.T) {
+	replicas := int32(3)
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		Status: appsv1.StatefulSetStatus{
+			ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3,
+		},
+	}
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Generation: 4},
+		Status: appsv1.DaemonSetStatus{
+			ObservedGeneration: 4, DesiredNumberScheduled: 5, CurrentNumberScheduled: 5,
+			UpdatedNumberScheduled: 5, NumberReady: 5, NumberAvailable: 5,
+		},
+	}
+	staleDaemonSet := daemonSet.DeepCopy()
+	staleDaemonSet.Generation = 5
+	emptyDeployment := rolledOutDeployment(nil)
+	zero := int32(0)
+	emptyDeployment.Spec.Replicas = &zero
+
+	tests := []struct {
+		name   string
+		obj    client.Object
+		expect bool
+	}{
+		{name: "StatefulSet rolled out", obj: statefulSet, expect: true},
+		{name: "DaemonSet rolled out", obj: daemonSet, expect: true},
+		{name: "DaemonSet spec not yet observed", obj: staleDaemonSet, expect: false},
+		{name: "Deployment scaled to zero", obj: emptyDeployment, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, complete := rolloutState(tt.obj); complete != tt.expect {
+				t.Errorf("Expected complete=%v, got %v", tt.expect, complete)
+			}
+		})
+	}
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package detectors
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+	"github.com/heal8s/heal8s/operator/internal/webhooks"
+)
+
+// Alert names produced by the detectors. They match the kube-prometheus alert
+// names so the same routing rules apply with or without Alertmanager.
+const (
+	AlertOOMKilled        = "KubePodOOMKilled"
+	AlertCrashLooping     = "KubePodCrashLooping"
+	AlertImagePullBackOff = "KubePodImagePullBackOff"
+)
+
+// Config enables the detectors and sets their thresholds
+type Config struct {
+	OOMKilled        OOMKilledConfig
+	CrashLoopBackOff CrashLoopBackOffConfig
+	ImagePullBackOff ImagePullBackOffConfig
+}
+
+// OOMKilledConfig fires after Threshold OOM kills of a container within Window
+type OOMKilledConfig struct {
+	Enabled   bool
+	Threshold int
+	Window    time.Duration
+}
+
+// CrashLoopBackOffConfig fires when a container is in CrashLoopBackOff after at least MinRestarts restarts
+type CrashLoopBackOffConfig struct {
+	Enabled     bool
+	MinRestarts int32
+}
+
+// ImagePullBackOffConfig fires when a container has failed to pull its image for at least For
+type ImagePullBackOffConfig struct {
+	Enabled bool
+	For     time.Duration
+}
+
+// DefaultConfig returns the detector defaults. All detectors are disabled.
+func DefaultConfig() Config {
+	return Config{
+		OOMKilled:        OOMKilledConfig{Threshold: 1, Window: 10 * time.Minute},
+		CrashLoopBackOff: CrashLoopBackOffConfig{MinRestarts: 3},
+		ImagePullBackOff: ImagePullBackOffConfig{For: 5 * time.Minute},
+	}
+}
+
+// AnyEnabled reports whether at least one detector is enabled
+func (c Config) AnyEnabled() bool {
+	return c.OOMKilled.Enabled || c.CrashLoopBackOff.Enabled || c.ImagePullBackOff.Enabled
+}
+
+// ConfigSource provides the current detector configuration
+type ConfigSource interface {
+	DetectorConfig() Config
+}
+
+// StaticConfig is a ConfigSource that never changes
+type StaticConfig Config
+
+// DetectorConfig returns the static configuration
+func (c StaticConfig) DetectorConfig() Config {
+	return Config(c)
+}
+
+// AlertSink receives the synthetic alerts produced by the detectors
+type AlertSink interface {
+	EnqueueAlert(source string, alert webhooks.AlertPayload) error
+}
+
+// containerKey identifies a container of a specific pod instance
+type containerKey struct {
+	pod       types.UID
+	container string
+}
+
+// containerState is what the detectors remember about a container
+type containerState struct {
+	// oomKills are the finish times of OOM kills within the window
+	oomKills []time.Time
+	// lastOOMKill is the last OOM kill already counted
+	lastOOMKill time.Time
+	// imagePullSince is when the container was first seen failing to pull its image
+	imagePullSince time.Time
+	// fired records detectors that already fired for this container
+	fired map[string]bool
+}
+
+// detection is an alert produced by evaluating a pod
+type detection struct {
+	key      containerKey
+	detector string
+	alert    webhooks.AlertPayload
+	// restore undoes the detector's state changes if the alert cannot be
+	// queued; nil for detectors that only record that they fired
+	restore func(*containerState)
+}
+
+// PodDetector watches Pod status through the manager's informers and turns
+// OOM kills, crash loops and image pull failures into alerts for the regular
+// routing pipeline
+type PodDetector struct {
+	informers cache.Informers
+	sink      AlertSink
+	config    ConfigSource
+	logger    logr.Logger
+	now       func() time.Time
+
+	mu         sync.Mutex
+	containers map[containerKey]*containerState
+}
+
+// NewPodDetector creates a detector reading pods from informers and sending alerts to sink
+func NewPodDetector(informers cache.Informers, sink AlertSink, config ConfigSource, logger logr.Logger) *PodDetector {
+	return &PodDetector{
+		informers:  informers,
+		sink:       sink,
+		config:     config,
+		logger:     logger,
+		now:        time.Now,
+		containers: make(map[containerKey]*containerState),
+	}
+}
+
+// Start registers the Pod event handlers and blocks until ctx is cancelled.
+// It implements manager.Runnable.
+func (d *PodDetector) Start(ctx context.Context) error {
+	informer, err := d.informers.GetInformer(ctx, &corev1.Pod{})
+	if err != nil {
+		return fmt.Errorf("failed to get pod informer: %w", err)
+	}
+
+	if _, err := informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				d.observe(pod)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if pod, ok := obj.(*corev1.Pod); ok {
+				d.observe(pod)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if pod, ok := obj.(*corev1.Pod); ok {
+				d.forget(pod.UID)
+			}
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to add pod event handler: %w", err)
+	}
+
+	d.logger.Info("Pod detectors started")
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection returns true so that only one replica emits alerts
+func (d *PodDetector) NeedLeaderElection() bool {
+	return true
+}
+
+// observe evaluates a pod and sends any resulting alerts
+func (d *PodDetector) observe(pod *corev1.Pod) {
+	for _, det := range d.evaluate(pod) {
+		if err := d.sink.EnqueueAlert(remediate.SourceDetector, det.alert); err != nil {
+			// Leave the detector armed so the next pod update retries
+			d.logger.Error(err, "Failed to queue detected alert",
+				"detector", det.detector,
+				"namespace", pod.Namespace,
+				"pod", pod.Name,
+				"container", det.key.container)
+			d.unmark(det)
+			continue
+		}
+		metrics.DetectorAlerts.WithLabelValues(det.detector).Inc()
+		d.logger.Info("Detected pod failure",
+			"alertname", det.alert.Labels["alertname"],
+			"namespace", pod.Namespace,
+			"pod", pod.Name,
+			"container", det.key.container)
+	}
+}
+
+// evaluate updates the per-container state from pod status and returns the
+// detectors that fired
+func (d *PodDetector) evaluate(pod *corev1.Pod) []detection {
+	config := d.config.DetectorConfig()
+	if !config.AnyEnabled() || pod.DeletionTimestamp != nil {
+		return nil
+	}
+
+	now := d.now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var detections []detection
+	for _, status := range pod.Status.ContainerStatuses {
+		key := containerKey{pod: pod.UID, container: status.Name}
+		state := d.containers[key]
+		if state == nil {
+			state = &containerState{fired: map[string]bool{}}
+			d.containers[key] = state
+		}
+
+		if config.OOMKilled.Enabled {
+			lastOOMKill, oomKills := state.lastOOMKill, append([]time.Time(nil), state.oomKills...)
+			if startsAt, ok := state.observeOOMKill(status, config.OOMKilled, now); ok {
+				detections = append(detections, detection{
+					key:      key,
+					detector: "oom-killed",
+					alert: newAlert(pod, status.Name, AlertOOMKilled, "critical", startsAt,
+						fmt.Sprintf("Container %s was OOM killed %d time(s) within %s",
+							status.Name, config.OOMKilled.Threshold, config.OOMKilled.Window)),
+					// Count the kill again on the next pod update
+					restore: func(s *containerState) {
+						s.lastOOMKill, s.oomKills = lastOOMKill, oomKills
+					},
+				})
+			}
+		}
+
+		if config.CrashLoopBackOff.Enabled && !state.fired["crash-loop"] {
+			if waiting := status.State.Waiting; waiting != nil && waiting.Reason == "CrashLoopBackOff" &&
+				status.RestartCount >= config.CrashLoopBackOff.MinRestarts {
+				state.fired["crash-loop"] = true
+				detections = append(detections, detection{
+					key:      key,
+					detector: "crash-loop",
+					alert: newAlert(pod, status.Name, AlertCrashLooping, "warning", now,
+						fmt.Sprintf("Container %s is in CrashLoopBackOff after %d restarts", status.Name, status.RestartCount)),
+				})
+			}
+		}
+
+		if config.ImagePullBackOff.Enabled {
+			waiting := status.State.Waiting
+			if waiting != nil && (waiting.Reason == "ImagePullBackOff" || waiting.Reason == "ErrImagePull") {
+				if state.imagePullSince.IsZero() {
+					state.imagePullSince = now
+				}
+				if !state.fired["image-pull"] && now.Sub(state.imagePullSince) >= config.ImagePullBackOff.For {
+					state.fired["image-pull"] = true
+					detections = append(detections, detection{
+						key:      key,
+						detector: "image-pull",
+						alert: newAlert(pod, status.Name, AlertImagePullBackOff, "warning", state.imagePullSince,
+							fmt.Sprintf("Container %s cannot pull image %s: %s", status.Name, status.Image, waiting.Reason)),
+					})
+				}
+			} else {
+				state.imagePullSince = time.Time{}
+			}
+		}
+	}
+
+	return detections
+}
+
+// observeOOMKill records a new OOM kill from the container's last
+// termination state and reports whether the threshold was reached
+func (s *containerState) observeOOMKill(status corev1.ContainerStatus, config OOMKilledConfig, now time.Time) (time.Time, bool) {
+	terminated := status.LastTerminationState.Terminated
+	if terminated == nil || terminated.Reason != "OOMKilled" {
+		return time.Time{}, false
+	}
+
+	killedAt := terminated.FinishedAt.Time
+	if killedAt.IsZero() {
+		killedAt = now
+	}
+	if !killedAt.After(s.lastOOMKill) {
+		// Already counted
+		return time.Time{}, false
+	}
+	s.lastOOMKill = killedAt
+
+	// A kill from before the window, e.g. seen for the first time after the
+	// operator restarted, does not count
+	cutoff := now.Add(-config.Window)
+	if !killedAt.After(cutoff) {
+		return time.Time{}, false
+	}
+	kills := s.oomKills[:0]
+	for _, t := range s.oomKills {
+		if t.After(cutoff) {
+			kills = append(kills, t)
+		}
+	}
+	s.oomKills = append(kills, killedAt)
+
+	if len(s.oomKills) < config.Threshold {
+		return time.Time{}, false
+	}
+
+	// Start counting again so the detector fires once per Threshold kills
+	s.oomKills = nil
+	return killedAt, true
+}
+
+// unmark re-arms a detector whose alert could not be queued
+func (d *PodDetector) unmark(det detection) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if state := d.containers[det.key]; state != nil {
+		delete(state.fired, det.detector)
+		if det.restore != nil {
+			det.restore(state)
+		}
+	}
+}
+
+// forget drops the state of a deleted pod
+func (d *PodDetector) forget(uid types.UID) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for key := range d.containers {
+		if key.pod == uid {
+			delete(d.containers, key)
+		}
+	}
+}
+
+// newAlert builds a firing alert in the shape Alertmanager sends, with a
+// fingerprint derived from its labels
+func newAlert(pod *corev1.Pod, container, alertname, severity string, startsAt time.Time, description string) webhooks.AlertPayload {
+	labels := map[string]string{
+		"alertname": alertname,
+		"namespace": pod.Namespace,
+		"pod":       pod.Name,
+		"container": container,
+		"severity":  severity,
+	}
+
+	return webhooks.AlertPayload{
+		Status: "firing",
+		Labels: labels,
+		Annotations: map[string]string{
+			"description": description,
+		},
+		StartsAt:    startsAt.Truncate(time.Second),
+		Fingerprint: webhooks.Fingerprint(labels),
+	}
+}
@@ -0,0 +1,200 @@
+package detectors
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/heal8s/heal8s/operator/internal/webhooks"
+)
+
+type recordingSink struct {
+	alerts []webhooks.AlertPayload
+	// failures is how many of the next alerts are refused
+	failures int
+}
+
+func (s *recordingSink) EnqueueAlert(source string, alert webhooks.AlertPayload) error {
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("alert queue is full")
+	}
+	s.alerts = append(s.alerts, alert)
+	return nil
+}
+
+func newTestDetector(config Config, now *time.Time) (*PodDetector, *recordingSink) {
+	sink := &recordingSink{}
+	d := NewPodDetector(nil, sink, StaticConfig(config), logr.Discard())
+	d.now = func() time.Time { return *now }
+	return d, sink
+}
+
+func podWithStatus(status corev1.ContainerStatus) *corev1.Pod {
+	status.Name = "app"
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-5f7b8c9d-xyz", Namespace: "prod", UID: "pod-1"},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{status},
+		},
+	}
+}
+
+func oomKilledAt(t time.Time, restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		RestartCount: restarts,
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: metav1.NewTime(t)},
+		},
+	}
+}
+
+func waiting(reason string, restarts int32) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		RestartCount: restarts,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: reason},
+		},
+	}
+}
+
+func TestPodDetector_OOMKilledThreshold(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.OOMKilled = OOMKilledConfig{Enabled: true, Threshold: 2, Window: 10 * time.Minute}
+	d, sink := newTestDetector(config, &now)
+
+	// First kill: below threshold
+	d.observe(podWithStatus(oomKilledAt(now.Add(-time.Minute), 1)))
+	// Same kill seen again is not counted twice
+	d.observe(podWithStatus(oomKilledAt(now.Add(-time.Minute), 1)))
+	if len(sink.alerts) != 0 {
+		t.Fatalf("expected no alert below threshold, got %d", len(sink.alerts))
+	}
+
+	// Second kill within the window fires
+	d.observe(podWithStatus(oomKilledAt(now, 2)))
+	if len(sink.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(sink.alerts))
+	}
+
+	alert := sink.alerts[0]
+	if alert.Labels["alertname"] != AlertOOMKilled || alert.Labels["pod"] != "api-5f7b8c9d-xyz" || alert.Labels["container"] != "app" {
+		t.Errorf("unexpected alert labels: %v", alert.Labels)
+	}
+	if alert.Status != "firing" || alert.Fingerprint == "" || !alert.StartsAt.Equal(now) {
+		t.Errorf("unexpected alert: %+v", alert)
+	}
+
+	// A kill outside the window of the previous one does not fire on its own
+	now = now.Add(30 * time.Minute)
+	d.observe(podWithStatus(oomKilledAt(now, 3)))
+	if len(sink.alerts) != 1 {
+		t.Errorf("expected counter to restart after firing, got %d alerts", len(sink.alerts))
+	}
+}
+
+func TestPodDetector_OOMKilledOutsideWindow(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	window := 10 * time.Minute
+	config.OOMKilled = OOMKilledConfig{Enabled: true, Threshold: 1, Window: window}
+	d, sink := newTestDetector(config, &now)
+
+	// A kill from long before the window, e.g. seen after an operator restart
+	d.observe(podWithStatus(oomKilledAt(now.Add(-2*window), 1)))
+	if len(sink.alerts) != 0 {
+		t.Fatalf("expected no alert for a kill outside the window, got %d", len(sink.alerts))
+	}
+
+	// A kill within the window still fires
+	d.observe(podWithStatus(oomKilledAt(now.Add(-time.Minute), 2)))
+	if len(sink.alerts) != 1 {
+		t.Errorf("expected 1 alert for a kill within the window, got %d", len(sink.alerts))
+	}
+}
+
+func TestPodDetector_OOMKilledRetriedAfterQueueFailure(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.OOMKilled = OOMKilledConfig{Enabled: true, Threshold: 2, Window: 10 * time.Minute}
+	d, sink := newTestDetector(config, &now)
+
+	d.observe(podWithStatus(oomKilledAt(now.Add(-time.Minute), 1)))
+
+	// The kill reaching the threshold cannot be queued
+	sink.failures = 1
+	d.observe(podWithStatus(oomKilledAt(now, 2)))
+	if len(sink.alerts) != 0 {
+		t.Fatalf("expected no alert while the queue is full, got %d", len(sink.alerts))
+	}
+
+	// The next update of the pod fires it
+	d.observe(podWithStatus(oomKilledAt(now, 2)))
+	if len(sink.alerts) != 1 {
+		t.Fatalf("expected 1 alert once the queue accepts it, got %d", len(sink.alerts))
+	}
+	if !sink.alerts[0].StartsAt.Equal(now) {
+		t.Errorf("expected the alert to start at the last kill, got %v", sink.alerts[0].StartsAt)
+	}
+}
+
+func TestPodDetector_CrashLoopBackOff(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.CrashLoopBackOff = CrashLoopBackOffConfig{Enabled: true, MinRestarts: 3}
+	d, sink := newTestDetector(config, &now)
+
+	d.observe(podWithStatus(waiting("CrashLoopBackOff", 2)))
+	if len(sink.alerts) != 0 {
+		t.Fatalf("expected no alert below minRestarts, got %d", len(sink.alerts))
+	}
+
+	d.observe(podWithStatus(waiting("CrashLoopBackOff", 3)))
+	d.observe(podWithStatus(waiting("CrashLoopBackOff", 4)))
+	if len(sink.alerts) != 1 {
+		t.Fatalf("expected exactly 1 alert, got %d", len(sink.alerts))
+	}
+	if sink.alerts[0].Labels["alertname"] != AlertCrashLooping {
+		t.Errorf("expected %s, got %s", AlertCrashLooping, sink.alerts[0].Labels["alertname"])
+	}
+}
+
+func TestPodDetector_ImagePullBackOff(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	config := DefaultConfig()
+	config.ImagePullBackOff = ImagePullBackOffConfig{Enabled: true, For: 5 * time.Minute}
+	d, sink := newTestDetector(config, &now)
+
+	d.observe(podWithStatus(waiting("ErrImagePull", 0)))
+	now = start.Add(2 * time.Minute)
+	d.observe(podWithStatus(waiting("ImagePullBackOff", 0)))
+	if len(sink.alerts) != 0 {
+		t.Fatalf("expected no alert before the duration, got %d", len(sink.alerts))
+	}
+
+	now = start.Add(6 * time.Minute)
+	d.observe(podWithStatus(waiting("ImagePullBackOff", 0)))
+	if len(sink.alerts) != 1 {
+		t.Fatalf("expected 1 alert, got %d", len(sink.alerts))
+	}
+	if !sink.alerts[0].StartsAt.Equal(start) {
+		t.Errorf("expected startsAt %s, got %s", start, sink.alerts[0].StartsAt)
+	}
+}
+
+func TestPodDetector_Disabled(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	d, sink := newTestDetector(DefaultConfig(), &now)
+
+	d.observe(podWithStatus(oomKilledAt(now, 1)))
+	d.observe(podWithStatus(waiting("CrashLoopBackOff", 10)))
+	if len(sink.alerts) != 0 {
+		t.Errorf("expected no alerts with all detectors disabled, got %d", len(sink.alerts))
+	}
+}
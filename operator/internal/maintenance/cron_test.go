@@ -0,0 +1,59 @@
+package maintenance
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2026, 3, 4, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{expr: "*/15 * * * *", expected: time.Date(2026, 3, 4, 10, 15, 0, 0, time.UTC)},
+		{expr: "0 22 * * *", expected: time.Date(2026, 3, 4, 22, 0, 0, 0, time.UTC)},
+		{expr: "0 9 * * *", expected: time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 2 * * 6", expected: time.Date(2026, 3, 7, 2, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", expected: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 5-7", expected: time.Date(2026, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{expr: "30 8 1 * *", expected: time.Date(2026, 4, 1, 8, 30, 0, 0, time.UTC)},
+		// Day of month and day of week are ORed: the 10th or any Monday
+		{expr: "0 0 10 * 1", expected: time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 1 *", expected: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", expected: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			schedule, err := ParseSchedule(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := schedule.Next(from); !got.Equal(tt.expected) {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
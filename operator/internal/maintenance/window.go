@@ -0,0 +1,315 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package maintenance evaluates MaintenanceWindows: when they are open and
+// whether they hold a remediation for a given target
+package maintenance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+// State is whether a window is open at a point in time and when that changes
+type State struct {
+	Active bool
+
+	// NextTransition is when the window next opens or closes; zero if never
+	NextTransition time.Time
+}
+
+// scheduleLookahead bounds how many scheduled opens and closes are examined
+// to find the next transition when schedule and ranges overlap
+const scheduleLookahead = 16
+
+// Evaluate returns the state of window at now
+func Evaluate(window *k8shealerv1alpha1.MaintenanceWindow, now time.Time) (State, error) {
+	w, err := compile(window.Spec)
+	if err != nil {
+		return State{}, err
+	}
+
+	state := State{Active: w.activeAt(now)}
+
+	// Candidate transitions are range boundaries and scheduled opens and
+	// closes; the first one where the state flips is the next transition
+	var candidates []time.Time
+	for _, r := range w.ranges {
+		candidates = append(candidates, r.Start.Time, r.End.Time)
+	}
+	if w.schedule != nil {
+		t := now
+		for i := 0; i < scheduleLookahead; i++ {
+			_, next := w.scheduleAt(t)
+			if next.IsZero() {
+				break
+			}
+			candidates = append(candidates, next)
+			t = next
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Before(candidates[j]) })
+	for _, t := range candidates {
+		if t.After(now) && w.activeAt(t) != state.Active {
+			state.NextTransition = t
+			break
+		}
+	}
+
+	return state, nil
+}
+
+// compiledWindow is a validated MaintenanceWindowSpec
+type compiledWindow struct {
+	ranges   []k8shealerv1alpha1.TimeRange
+	schedule *Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+func compile(spec k8shealerv1alpha1.MaintenanceWindowSpec) (*compiledWindow, error) {
+	if spec.Schedule == "" && len(spec.Ranges) == 0 {
+		return nil, fmt.Errorf("either schedule or ranges must be set")
+	}
+	w := &compiledWindow{ranges: spec.Ranges, location: time.UTC}
+	for i, r := range spec.Ranges {
+		if !r.End.After(r.Start.Time) {
+			return nil, fmt.Errorf("range %d ends before it starts", i)
+		}
+	}
+
+	if spec.Schedule == "" {
+		return w, nil
+	}
+	if spec.Duration == "" {
+		return nil, fmt.Errorf("duration is required with schedule")
+	}
+	duration, err := time.ParseDuration(spec.Duration)
+	if err != nil || duration <= 0 {
+		return nil, fmt.Errorf("invalid duration %q", spec.Duration)
+	}
+	w.duration = duration
+	if spec.TimeZone != "" {
+		if w.location, err = time.LoadLocation(spec.TimeZone); err != nil {
+			return nil, fmt.Errorf("invalid timeZone %q: %w", spec.TimeZone, err)
+		}
+	}
+	if w.schedule, err = ParseSchedule(spec.Schedule); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *compiledWindow) activeAt(t time.Time) bool {
+	for _, r := range w.ranges {
+		if !t.Before(r.Start.Time) && t.Before(r.End.Time) {
+			return true
+		}
+	}
+	if w.schedule != nil {
+		active, _ := w.scheduleAt(t)
+		return active
+	}
+	return false
+}
+
+// scheduleAt reports whether a scheduled window is open at t and when it
+// next opens or closes
+func (w *compiledWindow) scheduleAt(t time.Time) (bool, time.Time) {
+	local := t.In(w.location)
+	// The window is open if it started within the last duration. Back-to-back
+	// or overlapping starts extend it.
+	start := w.schedule.Next(local.Add(-w.duration - time.Minute))
+	if start.IsZero() || start.After(local) {
+		return false, start
+	}
+	end := start.Add(w.duration)
+	// A schedule that always overlaps itself is open indefinitely; stop
+	// extending once the end is far enough ahead to be irrelevant
+	for end.Sub(local) < maxSearchYears*365*24*time.Hour {
+		following := w.schedule.Next(start)
+		if following.IsZero() || following.After(end) {
+			break
+		}
+		start, end = following, following.Add(w.duration)
+	}
+	if !end.After(local) {
+		return false, w.schedule.Next(local)
+	}
+	return true, end
+}
+
+// Target is what a window is matched against: the labels of the target
+// workload and of its namespace
+type Target struct {
+	Namespace       string
+	NamespaceLabels map[string]string
+	Labels          map[string]string
+}
+
+// Selects reports whether window applies to target
+func Selects(window *k8shealerv1alpha1.MaintenanceWindow, target Target) (bool, error) {
+	for _, s := range []struct {
+		selector *metav1.LabelSelector
+		labels   map[string]string
+		field    string
+	}{
+		{window.Spec.NamespaceSelector, target.NamespaceLabels, "namespaceSelector"},
+		{window.Spec.Selector, target.Labels, "selector"},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s: %w", s.field, err)
+		}
+		if !selector.Matches(labels.Set(s.labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Hold explains why a remediation may not proceed
+type Hold struct {
+	// Windows are the names of the windows holding the remediation
+	Windows []string
+
+	// Reason is Freeze, OutsideAllowedWindow or InvalidWindow
+	Reason string
+
+	// Message is a human-readable explanation
+	Message string
+
+	// Until is when the hold is expected to end; zero if unknown
+	Until time.Time
+}
+
+// Decide applies the windows selecting target at now. An open Freeze window
+// holds the remediation, as does the absence of an open AllowedOnly window
+// when any select the target. A window that cannot be evaluated holds it
+// too, so a typo never lifts a freeze. It returns nil if the remediation may
+// proceed.
+func Decide(windows []k8shealerv1alpha1.MaintenanceWindow, target Target, now time.Time) *Hold {
+	var frozen, invalid, allowed []string
+	var frozenUntil, allowedFrom time.Time
+	var messages []string
+	allowedOnly, allowedOpen, frozenIndefinitely := false, false, false
+
+	for i := range windows {
+		window := &windows[i]
+		selected, err := Selects(window, target)
+		if err != nil {
+			invalid = append(invalid, window.Name)
+			messages = append(messages, fmt.Sprintf("MaintenanceWindow %s is invalid: %v", window.Name, err))
+			continue
+		}
+		if !selected {
+			continue
+		}
+
+		state, err := Evaluate(window, now)
+		if err != nil {
+			invalid = append(invalid, window.Name)
+			messages = append(messages, fmt.Sprintf("MaintenanceWindow %s is invalid: %v", window.Name, err))
+			continue
+		}
+
+		switch window.Spec.Type {
+		case k8shealerv1alpha1.MaintenanceWindowTypeFreeze:
+			if state.Active {
+				frozen = append(frozen, window.Name)
+				frozenUntil = latest(frozenUntil, state.NextTransition)
+				frozenIndefinitely = frozenIndefinitely || state.NextTransition.IsZero()
+			}
+		case k8shealerv1alpha1.MaintenanceWindowTypeAllowedOnly:
+			allowedOnly = true
+			if state.Active {
+				allowedOpen = true
+			} else {
+				allowed = append(allowed, window.Name)
+				allowedFrom = earliest(allowedFrom, state.NextTransition)
+			}
+		default:
+			invalid = append(invalid, window.Name)
+			messages = append(messages, fmt.Sprintf("MaintenanceWindow %s has unknown type %q", window.Name, window.Spec.Type))
+		}
+	}
+
+	switch {
+	case len(invalid) > 0:
+		sort.Strings(invalid)
+		return &Hold{Windows: invalid, Reason: "InvalidWindow", Message: strings.Join(messages, "; ")}
+	case len(frozen) > 0:
+		sort.Strings(frozen)
+		if frozenIndefinitely {
+			frozenUntil = time.Time{}
+		}
+		message := fmt.Sprintf("Change freeze by MaintenanceWindow %s", strings.Join(frozen, ", "))
+		if !frozenUntil.IsZero() {
+			message += fmt.Sprintf(" until %s", frozenUntil.UTC().Format(time.RFC3339))
+		}
+		return &Hold{Windows: frozen, Reason: "Freeze", Message: message, Until: frozenUntil}
+	case allowedOnly && !allowedOpen:
+		sort.Strings(allowed)
+		message := fmt.Sprintf("Outside the allowed MaintenanceWindow %s", strings.Join(allowed, ", "))
+		if !allowedFrom.IsZero() {
+			message += fmt.Sprintf("; next opens at %s", allowedFrom.UTC().Format(time.RFC3339))
+		}
+		return &Hold{Windows: allowed, Reason: "OutsideAllowedWindow", Message: message, Until: allowedFrom}
+	}
+	return nil
+}
+
+// NextChange returns the earliest time a window selecting target opens or
+// closes after now, so a decision made at now can be revisited; zero if none
+func NextChange(windows []k8shealerv1alpha1.MaintenanceWindow, target Target, now time.Time) time.Time {
+	var next time.Time
+	for i := range windows {
+		window := &windows[i]
+		if selected, err := Selects(window, target); err != nil || !selected {
+			continue
+		}
+		state, err := Evaluate(window, now)
+		if err != nil {
+			continue
+		}
+		next = earliest(next, state.NextTransition)
+	}
+	return next
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.IsZero() || (!b.IsZero() && b.Before(a)) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,181 @@
+package maintenance
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestEvaluate(t *testing.T) {
+	// Wednesday
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) metav1.Time {
+		return metav1.NewTime(time.Date(2026, 3, 4, hour, minute, 0, 0, time.UTC))
+	}
+
+	tests := []struct {
+		name         string
+		spec         k8shealerv1alpha1.MaintenanceWindowSpec
+		expectActive bool
+		expectNext   time.Time
+		expectError  bool
+	}{
+		{
+			name:         "inside a range",
+			spec:         k8shealerv1alpha1.MaintenanceWindowSpec{Ranges: []k8shealerv1alpha1.TimeRange{{Start: at(9, 0), End: at(12, 0)}}},
+			expectActive: true,
+			expectNext:   at(12, 0).Time,
+		},
+		{
+			name:       "before a range",
+			spec:       k8shealerv1alpha1.MaintenanceWindowSpec{Ranges: []k8shealerv1alpha1.TimeRange{{Start: at(11, 0), End: at(12, 0)}}},
+			expectNext: at(11, 0).Time,
+		},
+		{
+			name: "overlapping ranges close at the later end",
+			spec: k8shealerv1alpha1.MaintenanceWindowSpec{Ranges: []k8shealerv1alpha1.TimeRange{
+				{Start: at(9, 0), End: at(11, 0)},
+				{Start: at(10, 30), End: at(13, 0)},
+			}},
+			expectActive: true,
+			expectNext:   at(13, 0).Time,
+		},
+		{
+			name:         "inside a scheduled window",
+			spec:         k8shealerv1alpha1.MaintenanceWindowSpec{Schedule: "0 9 * * 1-5", Duration: "2h"},
+			expectActive: true,
+			expectNext:   at(11, 0).Time,
+		},
+		{
+			name:       "outside a scheduled window",
+			spec:       k8shealerv1alpha1.MaintenanceWindowSpec{Schedule: "0 22 * * *", Duration: "4h"},
+			expectNext: at(22, 0).Time,
+		},
+		{
+			name:         "schedule in a time zone",
+			spec:         k8shealerv1alpha1.MaintenanceWindowSpec{Schedule: "0 10 * * *", Duration: "1h", TimeZone: "Europe/Berlin"},
+			expectActive: false,
+			expectNext:   time.Date(2026, 3, 5, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "schedule without duration",
+			spec:        k8shealerv1alpha1.MaintenanceWindowSpec{Schedule: "0 9 * * *"},
+			expectError: true,
+		},
+		{
+			name:        "no schedule or ranges",
+			expectError: true,
+		},
+		{
+			name:        "range ends before it starts",
+			spec:        k8shealerv1alpha1.MaintenanceWindowSpec{Ranges: []k8shealerv1alpha1.TimeRange{{Start: at(12, 0), End: at(9, 0)}}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, err := Evaluate(&k8shealerv1alpha1.MaintenanceWindow{Spec: tt.spec}, now)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error: %v, got %v", tt.expectError, err)
+			}
+			if tt.expectError {
+				return
+			}
+			if state.Active != tt.expectActive {
+				t.Errorf("expected active %v, got %v", tt.expectActive, state.Active)
+			}
+			if !state.NextTransition.Equal(tt.expectNext) {
+				t.Errorf("expected next transition %s, got %s", tt.expectNext, state.NextTransition)
+			}
+		})
+	}
+}
+
+func TestDecide(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	open := []k8shealerv1alpha1.TimeRange{{
+		Start: metav1.NewTime(now.Add(-time.Hour)),
+		End:   metav1.NewTime(now.Add(time.Hour)),
+	}}
+	closed := []k8shealerv1alpha1.TimeRange{{
+		Start: metav1.NewTime(now.Add(2 * time.Hour)),
+		End:   metav1.NewTime(now.Add(3 * time.Hour)),
+	}}
+	window := func(name string, windowType k8shealerv1alpha1.MaintenanceWindowType, ranges []k8shealerv1alpha1.TimeRange) k8shealerv1alpha1.MaintenanceWindow {
+		return k8shealerv1alpha1.MaintenanceWindow{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       k8shealerv1alpha1.MaintenanceWindowSpec{Type: windowType, Ranges: ranges},
+		}
+	}
+	prodOnly := window("prod-freeze", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, open)
+	prodOnly.Spec.NamespaceSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	tierSelector := window("db-freeze", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, open)
+	tierSelector.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "db"}}
+	invalid := window("typo", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, nil)
+
+	target := Target{
+		Namespace:       "shop",
+		NamespaceLabels: map[string]string{"env": "prod"},
+		Labels:          map[string]string{"tier": "web"},
+	}
+
+	tests := []struct {
+		name          string
+		windows       []k8shealerv1alpha1.MaintenanceWindow
+		expectReason  string
+		expectMessage string
+	}{
+		{name: "no windows"},
+		{name: "closed freeze", windows: []k8shealerv1alpha1.MaintenanceWindow{window("release", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, closed)}},
+		{
+			name:          "open freeze",
+			windows:       []k8shealerv1alpha1.MaintenanceWindow{window("release", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, open)},
+			expectReason:  "Freeze",
+			expectMessage: "Change freeze by MaintenanceWindow release until 2026-03-04T11:00:00Z",
+		},
+		{name: "namespace selector matches", windows: []k8shealerv1alpha1.MaintenanceWindow{prodOnly}, expectReason: "Freeze"},
+		{name: "workload selector does not match", windows: []k8shealerv1alpha1.MaintenanceWindow{tierSelector}},
+		{name: "inside allowed window", windows: []k8shealerv1alpha1.MaintenanceWindow{window("nightly", k8shealerv1alpha1.MaintenanceWindowTypeAllowedOnly, open)}},
+		{
+			name:          "outside allowed window",
+			windows:       []k8shealerv1alpha1.MaintenanceWindow{window("nightly", k8shealerv1alpha1.MaintenanceWindowTypeAllowedOnly, closed)},
+			expectReason:  "OutsideAllowedWindow",
+			expectMessage: "next opens at 2026-03-04T12:00:00Z",
+		},
+		{
+			name: "freeze wins over allowed window",
+			windows: []k8shealerv1alpha1.MaintenanceWindow{
+				window("nightly", k8shealerv1alpha1.MaintenanceWindowTypeAllowedOnly, open),
+				window("release", k8shealerv1alpha1.MaintenanceWindowTypeFreeze, open),
+			},
+			expectReason: "Freeze",
+		},
+		{name: "invalid window holds", windows: []k8shealerv1alpha1.MaintenanceWindow{invalid}, expectReason: "InvalidWindow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hold := Decide(tt.windows, target, now)
+			if tt.expectReason == "" {
+				if hold != nil {
+					t.Errorf("expected no hold, got %+v", hold)
+				}
+				return
+			}
+			if hold == nil {
+				t.Fatalf("expected hold with reason %s", tt.expectReason)
+			}
+			if hold.Reason != tt.expectReason {
+				t.Errorf("expected reason %s, got %s", tt.expectReason, hold.Reason)
+			}
+			if !strings.Contains(hold.Message, tt.expectMessage) {
+				t.Errorf("expected message containing %q, got %q", tt.expectMessage, hold.Message)
+			}
+		})
+	}
+}
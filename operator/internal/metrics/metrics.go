@@ -6,13 +6,14 @@ import (
 )
 
 var (
-	// AlertsReceived counts the total number of alerts received from Alertmanager
+	// AlertsReceived counts the total number of alerts received, by source
+	// (alertmanager, grafana, generic, detector)
 	AlertsReceived = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "heal8s_alerts_received_total",
-			Help: "Total number of alerts received from Alertmanager",
+			Help: "Total number of alerts received",
 		},
-		[]string{"alertname", "severity"},
+		[]string{"source", "alertname", "severity"},
 	)
 
 	// RemediationsCreated counts the total number of Remediation CRs created
@@ -52,13 +53,24 @@ var (
 		[]string{"action_type", "phase"},
 	)
 
+	// AlertResolvedAfterApply tracks the time from a remediation being applied
+	// to its alert resolving, i.e. how quickly the fix worked
+	AlertResolvedAfterApply = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "heal8s_alert_resolved_after_apply_seconds",
+			Help:    "Time from remediation applied to the triggering alert resolving",
+			Buckets: []float64{30, 60, 120, 300, 600, 1800, 3600, 7200, 21600},
+		},
+		[]string{"action_type"},
+	)
+
 	// AlertsSkipped counts alerts that were skipped (deduplicated or filtered)
 	AlertsSkipped = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "heal8s_alerts_skipped_total",
 			Help: "Total number of alerts skipped",
 		},
-		[]string{"alertname", "reason"},
+		[]string{"source", "alertname", "reason"},
 	)
 
 	// RemediationPhaseTransitions tracks phase transitions
@@ -69,6 +81,60 @@ var (
 		},
 		[]string{"from_phase", "to_phase"},
 	)
+
+	// WebhookRequestsRejected counts webhook requests rejected before processing
+	WebhookRequestsRejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "heal8s_webhook_requests_rejected_total",
+			Help: "Total number of webhook requests rejected (auth, size, method, malformed)",
+		},
+		[]string{"reason"},
+	)
+
+	// AlertQueueDepth is the number of alerts waiting for a worker
+	AlertQueueDepth = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "heal8s_alert_queue_depth",
+			Help: "Number of alerts waiting to be processed",
+		},
+	)
+
+	// AlertQueueWait tracks how long alerts wait in the queue before a worker picks them up
+	AlertQueueWait = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "heal8s_alert_queue_wait_seconds",
+			Help:    "Time alerts spend queued before processing starts",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60},
+		},
+	)
+
+	// AlertProcessingDuration tracks how long processing an alert takes, including retries
+	AlertProcessingDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "heal8s_alert_processing_duration_seconds",
+			Help:    "Time taken to process an alert, including retries",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+		},
+		[]string{"status", "result"},
+	)
+
+	// DetectorAlerts counts alerts raised by the in-cluster pod detectors
+	DetectorAlerts = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "heal8s_detector_alerts_total",
+			Help: "Total number of alerts raised by in-cluster detectors",
+		},
+		[]string{"detector"},
+	)
+
+	// ConfigReloads counts operator config file loads by result (success, error)
+	ConfigReloads = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "heal8s_config_reloads_total",
+			Help: "Total number of operator config reloads",
+		},
+		[]string{"result"},
+	)
 )
 
 func init() {
@@ -81,5 +147,12 @@ func init() {
 		RemediationDuration,
 		AlertsSkipped,
 		RemediationPhaseTransitions,
+		ConfigReloads,
+		WebhookRequestsRejected,
+		AlertResolvedAfterApply,
+		AlertQueueDepth,
+		AlertQueueWait,
+		AlertProcessingDuration,
+		DetectorAlerts,
 	)
 }
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+// ErrUnsupportedAction is returned by ApplyAction for unknown action types
+var ErrUnsupportedAction = errors.New("unsupported action type")
+
+// NewTargetObject returns an empty object for a target kind
+func NewTargetObject(kind string) (client.Object, error) {
+	switch kind {
+	case "Deployment":
+		return &appsv1.Deployment{}, nil
+	case "StatefulSet":
+		return &appsv1.StatefulSet{}, nil
+	case "DaemonSet":
+		return &appsv1.DaemonSet{}, nil
+	case "Job":
+		return &batchv1.Job{}, nil
+	case "CronJob":
+		return &batchv1.CronJob{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported target kind: %s", kind)
+	}
+}
+
+// ApplyAction applies a remediation action to obj in memory. Nothing is
+// written to the cluster; cl is only used for lookups.
+func ApplyAction(ctx context.Context, cl client.Client, obj client.Object, target k8shealerv1alpha1.TargetResource, action k8shealerv1alpha1.Action) error {
+	switch action.Type {
+	case k8shealerv1alpha1.ActionTypeIncreaseMemory:
+		return ApplyIncreaseMemory(obj, target.Container, action.Params)
+	case k8shealerv1alpha1.ActionTypeIncreaseCPU:
+		return ApplyIncreaseCPU(obj, target.Container, action.Params)
+	case k8shealerv1alpha1.ActionTypeScaleUp:
+		return ApplyScaleUp(obj, action.Params)
+	case k8shealerv1alpha1.ActionTypeRestartPods:
+		return ApplyRestartPods(obj, action.Params, time.Now())
+	case k8shealerv1alpha1.ActionTypeRollbackImage:
+		_, err := ApplyRollbackImage(ctx, cl, obj, target.Container, action.Params)
+		return err
+	default:
+		return fmt.Errorf("%w: %s", ErrUnsupportedAction, action.Type)
+	}
+}
+
+// Change is a single field changed by a remediation
+type Change struct {
+	// Path is the field path, e.g. spec.template.spec.containers[0].resources.limits.memory
+	Path   string `json:"path"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// DiffObjects lists the spec and annotation fields that differ between before and after
+func DiffObjects(before, after client.Object) ([]Change, error) {
+	b, err := runtime.DefaultUnstructuredConverter.ToUnstructured(before)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object: %w", err)
+	}
+	a, err := runtime.DefaultUnstructuredConverter.ToUnstructured(after)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert object: %w", err)
+	}
+
+	beforeFields := map[string]string{}
+	afterFields := map[string]string{}
+	flatten("spec", b["spec"], beforeFields)
+	flatten("spec", a["spec"], afterFields)
+	if meta, ok := b["metadata"].(map[string]interface{}); ok {
+		flatten("metadata.annotations", meta["annotations"], beforeFields)
+	}
+	if meta, ok := a["metadata"].(map[string]interface{}); ok {
+		flatten("metadata.annotations", meta["annotations"], afterFields)
+	}
+
+	var changes []Change
+	for path, value := range afterFields {
+		if beforeFields[path] != value {
+			changes = append(changes, Change{Path: path, Before: beforeFields[path], After: value})
+		}
+	}
+	for path, value := range beforeFields {
+		if _, ok := afterFields[path]; !ok {
+			changes = append(changes, Change{Path: path, Before: value})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+
+	return changes, nil
+}
+
+// flatten records the leaf values of an unstructured value by field path
+func flatten(path string, value interface{}, out map[string]string) {
+	switch v := value.(type) {
+	case nil:
+	case map[string]interface{}:
+		for key, child := range v {
+			flatten(path+"."+key, child, out)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flatten(fmt.Sprintf("%s[%d]", path, i), child, out)
+		}
+	default:
+		out[path] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ApplyIncreaseCPU raises the CPU limit of a container, or removes it when
+// the removeCPULimit param is true. A container whose CPU request equals its
+// limit keeps them equal so its QoS class does not change, and its limit is
+// never removed.
+func ApplyIncreaseCPU(obj client.Object, containerName string, params map[string]string) error {
+	// Parse parameters
+	increasePercent := 50 // default
+	if p, ok := params["cpuIncreasePercent"]; ok {
+		if val, err := strconv.Atoi(p); err == nil {
+			increasePercent = val
+		}
+	}
+
+	roundTo := resource.MustParse("100m") // default
+	if r, ok := params["cpuRoundTo"]; ok {
+		if parsed, err := resource.ParseQuantity(r); err == nil {
+			roundTo = parsed
+		}
+	}
+
+	maxCPU := resource.MustParse("4") // default
+	if m, ok := params["maxCPU"]; ok {
+		if parsed, err := resource.ParseQuantity(m); err == nil {
+			maxCPU = parsed
+		}
+	}
+
+	removeLimit, _ := strconv.ParseBool(params["removeCPULimit"])
+
+	var containers []corev1.Container
+	switch v := obj.(type) {
+	case *appsv1.Deployment:
+		containers = v.Spec.Template.Spec.Containers
+	case *appsv1.StatefulSet:
+		containers = v.Spec.Template.Spec.Containers
+	case *appsv1.DaemonSet:
+		containers = v.Spec.Template.Spec.Containers
+	default:
+		return fmt.Errorf("unsupported object type: %T", obj)
+	}
+
+	// Find the target container
+	containerIndex := -1
+	if containerName == "" && len(containers) == 1 {
+		containerIndex = 0
+	} else {
+		for i, c := range containers {
+			if c.Name == containerName {
+				containerIndex = i
+				break
+			}
+		}
+	}
+
+	if containerIndex == -1 {
+		return fmt.Errorf("container %s not found", containerName)
+	}
+
+	// containers shares its backing array with obj, so changes apply in place
+	container := &containers[containerIndex]
+
+	// Throttling only happens under a CPU limit, so there must be one
+	currentCPU, ok := container.Resources.Limits[corev1.ResourceCPU]
+	if !ok || currentCPU.IsZero() {
+		return fmt.Errorf("container %s has no CPU limit", container.Name)
+	}
+
+	if removeLimit {
+		// A missing request defaults to the limit. Dropping a limit equal to the
+		// request would move the pod out of the Guaranteed QoS class.
+		if request, ok := container.Resources.Requests[corev1.ResourceCPU]; !ok || request.Cmp(currentCPU) == 0 {
+			return fmt.Errorf("container %s has a CPU request equal to its limit; removing the limit would change its QoS class", container.Name)
+		}
+		delete(container.Resources.Limits, corev1.ResourceCPU)
+		return nil
+	}
+
+	if currentCPU.Cmp(maxCPU) >= 0 {
+		return fmt.Errorf("CPU limit %s is already at or above maxCPU %s", currentCPU.String(), maxCPU.String())
+	}
+
+	newCPU := CalculateCPUIncrease(currentCPU, increasePercent, roundTo, maxCPU)
+
+	request, hasRequest := container.Resources.Requests[corev1.ResourceCPU]
+	if hasRequest && request.Cmp(currentCPU) == 0 {
+		container.Resources.Requests[corev1.ResourceCPU] = newCPU
+	}
+	container.Resources.Limits[corev1.ResourceCPU] = newCPU
+
+	return nil
+}
+
+// CalculateCPUIncrease calculates the new CPU value without applying it. The
+// result is rounded up to a multiple of roundTo and capped at max.
+func CalculateCPUIncrease(current resource.Quantity, percent int, roundTo, max resource.Quantity) resource.Quantity {
+	newMilli := (current.MilliValue()*int64(100+percent) + 99) / 100
+
+	if step := roundTo.MilliValue(); step > 0 {
+		newMilli = ((newMilli + step - 1) / step) * step
+	}
+
+	newCPU := *resource.NewMilliQuantity(newMilli, resource.DecimalSI)
+
+	if newCPU.Cmp(max) > 0 {
+		return max
+	}
+
+	return newCPU
+}
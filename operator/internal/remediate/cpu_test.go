@@ -0,0 +1,181 @@
+package remediate
+
+import (
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestCalculateCPUIncrease(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  string
+		percent  int
+		roundTo  string
+		max      string
+		expected string
+	}{
+		{
+			name:     "50% increase from 500m",
+			current:  "500m",
+			percent:  50,
+			roundTo:  "50m",
+			max:      "4",
+			expected: "750m",
+		},
+		{
+			name:     "rounded up to the step",
+			current:  "300m",
+			percent:  25,
+			roundTo:  "250m",
+			max:      "4",
+			expected: "500m",
+		},
+		{
+			name:     "whole cores",
+			current:  "1",
+			percent:  50,
+			roundTo:  "1",
+			max:      "8",
+			expected: "2",
+		},
+		{
+			name:     "cap at max CPU",
+			current:  "3",
+			percent:  50,
+			roundTo:  "100m",
+			max:      "4",
+			expected: "4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := CalculateCPUIncrease(resource.MustParse(tt.current), tt.percent, resource.MustParse(tt.roundTo), resource.MustParse(tt.max))
+
+			expected := resource.MustParse(tt.expected)
+			if result.Cmp(expected) != 0 {
+				t.Errorf("expected %s, got %s", expected.String(), result.String())
+			}
+		})
+	}
+}
+
+func TestApplyIncreaseCPU(t *testing.T) {
+	deployment := func(resources corev1.ResourceRequirements) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "sidecar", Image: "envoy"},
+							{Name: "app", Image: "nginx", Resources: resources},
+						},
+					},
+				},
+			},
+		}
+	}
+	cpu := func(q string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(q)}
+	}
+
+	tests := []struct {
+		name          string
+		resources     corev1.ResourceRequirements
+		containerName string
+		params        map[string]string
+		expectError   bool
+		expectLimit   string
+		expectRequest string
+	}{
+		{
+			name:          "raises the limit and leaves a lower request",
+			resources:     corev1.ResourceRequirements{Limits: cpu("500m"), Requests: cpu("200m")},
+			containerName: "app",
+			params:        map[string]string{"cpuIncreasePercent": "50", "maxCPU": "2"},
+			expectLimit:   "800m",
+			expectRequest: "200m",
+		},
+		{
+			name:          "keeps a request equal to the limit",
+			resources:     corev1.ResourceRequirements{Limits: cpu("1"), Requests: cpu("1")},
+			containerName: "app",
+			params:        map[string]string{"cpuIncreasePercent": "20", "cpuRoundTo": "500m"},
+			expectLimit:   "1500m",
+			expectRequest: "1500m",
+		},
+		{
+			name:          "removes the limit",
+			resources:     corev1.ResourceRequirements{Limits: cpu("500m"), Requests: cpu("200m")},
+			containerName: "app",
+			params:        map[string]string{"removeCPULimit": "true"},
+			expectRequest: "200m",
+		},
+		{
+			name:          "keeps the limit when the request equals it",
+			resources:     corev1.ResourceRequirements{Limits: cpu("500m"), Requests: cpu("500m")},
+			containerName: "app",
+			params:        map[string]string{"removeCPULimit": "true"},
+			expectError:   true,
+		},
+		{
+			name:          "keeps the limit when there is no request",
+			resources:     corev1.ResourceRequirements{Limits: cpu("500m")},
+			containerName: "app",
+			params:        map[string]string{"removeCPULimit": "true"},
+			expectError:   true,
+		},
+		{
+			name:          "no CPU limit",
+			resources:     corev1.ResourceRequirements{Requests: cpu("200m")},
+			containerName: "app",
+			expectError:   true,
+		},
+		{
+			name:          "already at max",
+			resources:     corev1.ResourceRequirements{Limits: cpu("4")},
+			containerName: "app",
+			params:        map[string]string{"maxCPU": "4"},
+			expectError:   true,
+		},
+		{
+			name:          "container required with several containers",
+			resources:     corev1.ResourceRequirements{Limits: cpu("500m")},
+			containerName: "",
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := deployment(tt.resources)
+			err := ApplyIncreaseCPU(d, tt.containerName, tt.params)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error: %v, got: %v", tt.expectError, err)
+			}
+			if tt.expectError {
+				return
+			}
+
+			container := d.Spec.Template.Spec.Containers[1]
+			limit, hasLimit := container.Resources.Limits[corev1.ResourceCPU]
+			if tt.expectLimit == "" {
+				if hasLimit {
+					t.Errorf("expected no CPU limit, got %s", limit.String())
+				}
+			} else if limit.Cmp(resource.MustParse(tt.expectLimit)) != 0 {
+				t.Errorf("expected limit %s, got %s", tt.expectLimit, limit.String())
+			}
+			request := container.Resources.Requests[corev1.ResourceCPU]
+			if request.Cmp(resource.MustParse(tt.expectRequest)) != 0 {
+				t.Errorf("expected request %s, got %s", tt.expectRequest, request.String())
+			}
+			if len(d.Spec.Template.Spec.Containers[0].Resources.Limits) != 0 {
+				t.Error("expected other containers to be left alone")
+			}
+		})
+	}
+}
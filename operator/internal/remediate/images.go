@@ -0,0 +1,286 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationStableImages records on a workload the images, per container,
+	// of its last rollout that was known to be healthy, as JSON ImageSet
+	AnnotationStableImages = "heal8s.io/stable-images"
+
+	// AnnotationStableImagesHistory records on a workload the image sets that
+	// were recorded as stable, newest first, as a JSON list of ImageSet
+	AnnotationStableImagesHistory = "heal8s.io/stable-images-history"
+
+	// AnnotationAttemptedImages records on a workload the images, per
+	// container, that the last rollback replaced, as JSON ImageSet. Rollbacks
+	// do not restore them until they are recorded as stable again.
+	AnnotationAttemptedImages = "heal8s.io/attempted-images"
+
+	// AnnotationLastStableImage is the older single-image form of
+	// AnnotationStableImages, still read when set by hand
+	AnnotationLastStableImage = "heal8s.io/last-stable-image"
+
+	// DefaultStableImagesHistory is how many stable image sets are kept in
+	// the heal8s.io/stable-images-history annotation
+	DefaultStableImagesHistory = 5
+)
+
+// ImageSet holds container images by container name
+type ImageSet struct {
+	Containers     map[string]string `json:"containers,omitempty"`
+	InitContainers map[string]string `json:"initContainers,omitempty"`
+}
+
+// IsEmpty reports whether the set holds no images
+func (s ImageSet) IsEmpty() bool {
+	return len(s.Containers) == 0 && len(s.InitContainers) == 0
+}
+
+// Equal reports whether both sets hold the same images
+func (s ImageSet) Equal(other ImageSet) bool {
+	return maps.Equal(s.Containers, other.Containers) && maps.Equal(s.InitContainers, other.InitContainers)
+}
+
+// TemplateImages returns the images of a pod template's containers and init
+// containers
+func TemplateImages(template *corev1.PodTemplateSpec) ImageSet {
+	return ImageSet{
+		Containers:     containerImages(template.Spec.Containers),
+		InitContainers: containerImages(template.Spec.InitContainers),
+	}
+}
+
+func containerImages(containers []corev1.Container) map[string]string {
+	if len(containers) == 0 {
+		return nil
+	}
+	images := make(map[string]string, len(containers))
+	for _, c := range containers {
+		images[c.Name] = c.Image
+	}
+	return images
+}
+
+// imageChanges returns the images in stable that differ from template's. If
+// containerName is set only that container is considered, otherwise every
+// container. Init containers are always considered, since they are changed by
+// the same rollouts as the containers they prepare for.
+func imageChanges(template *corev1.PodTemplateSpec, stable ImageSet, containerName string) ImageSet {
+	changes := ImageSet{}
+	for _, c := range template.Spec.Containers {
+		if containerName != "" && c.Name != containerName {
+			continue
+		}
+		if image, ok := stable.Containers[c.Name]; ok && image != "" && image != c.Image {
+			if changes.Containers == nil {
+				changes.Containers = map[string]string{}
+			}
+			changes.Containers[c.Name] = image
+		}
+	}
+	for _, c := range template.Spec.InitContainers {
+		if image, ok := stable.InitContainers[c.Name]; ok && image != "" && image != c.Image {
+			if changes.InitContainers == nil {
+				changes.InitContainers = map[string]string{}
+			}
+			changes.InitContainers[c.Name] = image
+		}
+	}
+	return changes
+}
+
+// knownBad reports whether any image in changes is the one recorded for the
+// same container in bad
+func knownBad(changes, bad ImageSet) bool {
+	for name, image := range changes.Containers {
+		if bad.Containers[name] == image {
+			return true
+		}
+	}
+	for name, image := range changes.InitContainers {
+		if bad.InitContainers[name] == image {
+			return true
+		}
+	}
+	return false
+}
+
+// setImages sets the images in changes on template and returns the images
+// they replaced
+func setImages(template *corev1.PodTemplateSpec, changes ImageSet) ImageSet {
+	replaced := ImageSet{}
+	for i := range template.Spec.Containers {
+		c := &template.Spec.Containers[i]
+		if image, ok := changes.Containers[c.Name]; ok {
+			if replaced.Containers == nil {
+				replaced.Containers = map[string]string{}
+			}
+			replaced.Containers[c.Name] = c.Image
+			c.Image = image
+		}
+	}
+	for i := range template.Spec.InitContainers {
+		c := &template.Spec.InitContainers[i]
+		if image, ok := changes.InitContainers[c.Name]; ok {
+			if replaced.InitContainers == nil {
+				replaced.InitContainers = map[string]string{}
+			}
+			replaced.InitContainers[c.Name] = c.Image
+			c.Image = image
+		}
+	}
+	return replaced
+}
+
+// StableImages returns the stable images recorded on a workload, or nil if
+// none are. The older heal8s.io/last-stable-image annotation names a single
+// image, which is taken to be containerName's, or the only container's.
+func StableImages(obj client.Object, template *corev1.PodTemplateSpec, containerName string) (*ImageSet, error) {
+	stable, err := imageSetAnnotation(obj, AnnotationStableImages)
+	if err != nil || stable != nil {
+		return stable, err
+	}
+
+	if image := obj.GetAnnotations()[AnnotationLastStableImage]; image != "" {
+		name := containerName
+		if name == "" {
+			if len(template.Spec.Containers) != 1 {
+				return nil, fmt.Errorf("%s names one image but the pod has %d containers; set the target container", AnnotationLastStableImage, len(template.Spec.Containers))
+			}
+			name = template.Spec.Containers[0].Name
+		}
+		return &ImageSet{Containers: map[string]string{name: image}}, nil
+	}
+
+	return nil, nil
+}
+
+// StableImagesHistory returns the image sets recorded as stable on a
+// workload, newest first
+func StableImagesHistory(obj client.Object) ([]ImageSet, error) {
+	value := obj.GetAnnotations()[AnnotationStableImagesHistory]
+	if value == "" {
+		return nil, nil
+	}
+	var history []ImageSet
+	if err := json.Unmarshal([]byte(value), &history); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", AnnotationStableImagesHistory, err)
+	}
+	return history, nil
+}
+
+// AttemptedImages returns the images the last rollback of a workload
+// replaced, or nil if none are recorded
+func AttemptedImages(obj client.Object) (*ImageSet, error) {
+	return imageSetAnnotation(obj, AnnotationAttemptedImages)
+}
+
+// MarkImagesAsStable records the current images of every container and init
+// container of a workload as stable
+func MarkImagesAsStable(obj client.Object, template *corev1.PodTemplateSpec) error {
+	return setImageSetAnnotation(obj, AnnotationStableImages, TemplateImages(template))
+}
+
+// RecordStableImages marks the current images of a workload as stable and
+// adds them to the front of its stable-images history, which is cut to
+// historyLimit entries. Images that were recorded as replaced by a rollback
+// are no longer avoided once they are stable. It returns false, leaving obj
+// unchanged, if the current images are already the stable ones.
+func RecordStableImages(obj client.Object, template *corev1.PodTemplateSpec, historyLimit int) (bool, error) {
+	current := TemplateImages(template)
+
+	stable, err := imageSetAnnotation(obj, AnnotationStableImages)
+	if err != nil {
+		return false, err
+	}
+	if stable != nil && stable.Equal(current) {
+		return false, nil
+	}
+
+	// A hand-edited history that cannot be read is replaced rather than
+	// blocking stable images from being recorded
+	previous, _ := StableImagesHistory(obj)
+	history := []ImageSet{current}
+	for _, images := range previous {
+		if len(history) >= historyLimit {
+			break
+		}
+		if !images.Equal(current) {
+			history = append(history, images)
+		}
+	}
+	value, err := json.Marshal(history)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode %s: %w", AnnotationStableImagesHistory, err)
+	}
+
+	if err := MarkImagesAsStable(obj, template); err != nil {
+		return false, err
+	}
+	annotations := obj.GetAnnotations()
+	annotations[AnnotationStableImagesHistory] = string(value)
+
+	obj.SetAnnotations(annotations)
+
+	attempted, err := AttemptedImages(obj)
+	if err != nil || attempted == nil {
+		return true, nil
+	}
+	maps.DeleteFunc(attempted.Containers, func(name, image string) bool { return current.Containers[name] == image })
+	maps.DeleteFunc(attempted.InitContainers, func(name, image string) bool { return current.InitContainers[name] == image })
+	if attempted.IsEmpty() {
+		delete(annotations, AnnotationAttemptedImages)
+		obj.SetAnnotations(annotations)
+		return true, nil
+	}
+	return true, setImageSetAnnotation(obj, AnnotationAttemptedImages, *attempted)
+}
+
+func imageSetAnnotation(obj client.Object, key string) (*ImageSet, error) {
+	value := obj.GetAnnotations()[key]
+	if value == "" {
+		return nil, nil
+	}
+	images := &ImageSet{}
+	if err := json.Unmarshal([]byte(value), images); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %w", key, err)
+	}
+	return images, nil
+}
+
+func setImageSetAnnotation(obj client.Object, key string, images ImageSet) error {
+	value, err := json.Marshal(images)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = string(value)
+	obj.SetAnnotations(annotations)
+	return nil
+}
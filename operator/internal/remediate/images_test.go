@@ -0,0 +1,98 @@
+package remediate
+
+import (
+	"encoding/json"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMarkImagesAsStable(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Spec.Template.Spec = corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "migrate", Image: "migrate:v1"}},
+		Containers: []corev1.Container{
+			{Name: "app", Image: "api:v1"},
+			{Name: "proxy", Image: "envoy:1.29"},
+		},
+	}
+
+	if err := MarkImagesAsStable(deployment, &deployment.Spec.Template); err != nil {
+		t.Fatalf("MarkImagesAsStable failed: %v", err)
+	}
+
+	var recorded ImageSet
+	if err := json.Unmarshal([]byte(deployment.Annotations[AnnotationStableImages]), &recorded); err != nil {
+		t.Fatalf("Expected JSON in %s: %v", AnnotationStableImages, err)
+	}
+	if recorded.Containers["app"] != "api:v1" || recorded.Containers["proxy"] != "envoy:1.29" {
+		t.Errorf("Expected every container's image, got %v", recorded.Containers)
+	}
+	if recorded.InitContainers["migrate"] != "migrate:v1" {
+		t.Errorf("Expected init container images, got %v", recorded.InitContainers)
+	}
+
+	stable, err := StableImages(deployment, &deployment.Spec.Template, "")
+	if err != nil || stable == nil || stable.Containers["proxy"] != "envoy:1.29" {
+		t.Errorf("Expected StableImages to read the annotation back, got %v, %v", stable, err)
+	}
+}
+
+func TestStableImages_InvalidAnnotation(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Annotations = map[string]string{AnnotationStableImages: "api:v1"}
+
+	if _, err := StableImages(deployment, &deployment.Spec.Template, ""); err == nil {
+		t.Errorf("Expected error for a non-JSON annotation")
+	}
+}
+
+func TestRecordStableImages(t *testing.T) {
+	deployment := &appsv1.Deployment{}
+	deployment.Annotations = map[string]string{
+		AnnotationAttemptedImages: `{"containers":{"app":"api:v3","proxy":"envoy:1.30"}}`,
+	}
+	template := &deployment.Spec.Template
+	rollout := func(image string) {
+		template.Spec.Containers = []corev1.Container{{Name: "app", Image: image}, {Name: "proxy", Image: "envoy:1.29"}}
+	}
+
+	for _, image := range []string{"api:v1", "api:v2", "api:v1", "api:v3"} {
+		rollout(image)
+		recorded, err := RecordStableImages(deployment, template, 2)
+		if err != nil || !recorded {
+			t.Fatalf("Expected %s to be recorded, got %v, %v", image, recorded, err)
+		}
+	}
+
+	recorded, err := RecordStableImages(deployment, template, 2)
+	if err != nil || recorded {
+		t.Errorf("Expected the current stable images not to be recorded again, got %v, %v", recorded, err)
+	}
+
+	history, err := StableImagesHistory(deployment)
+	if err != nil {
+		t.Fatalf("StableImagesHistory failed: %v", err)
+	}
+	var got []string
+	for _, images := range history {
+		got = append(got, images.Containers["app"])
+	}
+	if len(got) != 2 || got[0] != "api:v3" || got[1] != "api:v1" {
+		t.Errorf("Expected history [api:v3 api:v1], newest first without duplicates, got %v", got)
+	}
+
+	stable, err := StableImages(deployment, template, "")
+	if err != nil || stable == nil || stable.Containers["app"] != "api:v3" {
+		t.Errorf("Expected api:v3 to be the stable image, got %v, %v", stable, err)
+	}
+
+	attempted, err := AttemptedImages(deployment)
+	if err != nil || attempted == nil {
+		t.Fatalf("Expected attempted images to remain, got %v, %v", attempted, err)
+	}
+	if _, ok := attempted.Containers["app"]; ok || attempted.Containers["proxy"] != "envoy:1.30" {
+		t.Errorf("Expected only the now stable api:v3 to be forgotten as attempted, got %v", attempted.Containers)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MatchType is the comparison operator of a label matcher
+type MatchType string
+
+const (
+	MatchEqual     MatchType = "="
+	MatchNotEqual  MatchType = "!="
+	MatchRegexp    MatchType = "=~"
+	MatchNotRegexp MatchType = "!~"
+)
+
+// Matcher matches a single alert label, with the same semantics as
+// Alertmanager matchers: a missing label is treated as the empty string and
+// regular expressions are fully anchored.
+type Matcher struct {
+	Name  string
+	Type  MatchType
+	Value string
+
+	re *regexp.Regexp
+}
+
+// NewMatcher creates a matcher, compiling the value if it is a regex matcher
+func NewMatcher(matchType MatchType, name, value string) (*Matcher, error) {
+	if name == "" {
+		return nil, fmt.Errorf("matcher has an empty label name")
+	}
+
+	m := &Matcher{Name: name, Type: matchType, Value: value}
+
+	switch matchType {
+	case MatchEqual, MatchNotEqual:
+	case MatchRegexp, MatchNotRegexp:
+		re, err := regexp.Compile("^(?:" + value + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("matcher %s: invalid regex %q: %w", name, value, err)
+		}
+		m.re = re
+	default:
+		return nil, fmt.Errorf("matcher %s: unknown match type %q", name, matchType)
+	}
+
+	return m, nil
+}
+
+// ParseMatcher parses a matcher in Alertmanager syntax, e.g.
+// `namespace=~"batch-.*"`, `severity!=info` or `alertname="KubePodOOMKilled"`.
+// The value may be quoted or bare.
+func ParseMatcher(s string) (*Matcher, error) {
+	s = strings.TrimSpace(s)
+
+	// Find the first operator character; label names cannot contain = ! or ~
+	idx := strings.IndexAny(s, "=!")
+	if idx <= 0 {
+		return nil, fmt.Errorf("invalid matcher %q: expected <label><op><value>", s)
+	}
+
+	name := strings.TrimSpace(s[:idx])
+	rest := s[idx:]
+
+	var matchType MatchType
+	switch {
+	case strings.HasPrefix(rest, string(MatchRegexp)):
+		matchType = MatchRegexp
+	case strings.HasPrefix(rest, string(MatchNotRegexp)):
+		matchType = MatchNotRegexp
+	case strings.HasPrefix(rest, string(MatchNotEqual)):
+		matchType = MatchNotEqual
+	case strings.HasPrefix(rest, string(MatchEqual)):
+		matchType = MatchEqual
+	default:
+		return nil, fmt.Errorf("invalid matcher %q: unknown operator", s)
+	}
+
+	value := strings.TrimSpace(rest[len(matchType):])
+	if strings.HasPrefix(value, `"`) {
+		unquoted, err := strconv.Unquote(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: bad quoting: %w", s, err)
+		}
+		value = unquoted
+	}
+
+	return NewMatcher(matchType, name, value)
+}
+
+// MustParseMatcher is like ParseMatcher but panics on error. It is intended
+// for built-in configuration.
+func MustParseMatcher(s string) *Matcher {
+	m, err := ParseMatcher(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Matches reports whether the label set satisfies the matcher
+func (m *Matcher) Matches(labels map[string]string) bool {
+	v := labels[m.Name]
+
+	switch m.Type {
+	case MatchEqual:
+		return v == m.Value
+	case MatchNotEqual:
+		return v != m.Value
+	case MatchRegexp:
+		return m.re.MatchString(v)
+	case MatchNotRegexp:
+		return !m.re.MatchString(v)
+	}
+
+	return false
+}
+
+// String returns the matcher in Alertmanager syntax
+func (m *Matcher) String() string {
+	return fmt.Sprintf("%s%s%q", m.Name, m.Type, m.Value)
+}
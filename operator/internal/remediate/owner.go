@@ -0,0 +1,91 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrUnsupportedOwner is returned when a pod is not managed by a workload
+// heal8s can remediate
+var ErrUnsupportedOwner = errors.New("unsupported owner")
+
+// ResolvePodOwner walks the controller ownerReferences of a pod up to the
+// workload that manages it: Pod -> ReplicaSet -> Deployment, Pod -> StatefulSet,
+// Pod -> DaemonSet and Pod -> Job -> CronJob. Pods without a supported owner
+// return an error wrapping ErrUnsupportedOwner.
+func ResolvePodOwner(ctx context.Context, cl client.Reader, namespace, podName string) (kind, name string, err error) {
+	pod := &corev1.Pod{}
+	if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: podName}, pod); err != nil {
+		return "", "", fmt.Errorf("failed to get pod %s/%s: %w", namespace, podName, err)
+	}
+
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return "", "", fmt.Errorf("%w: pod %s/%s has no controller", ErrUnsupportedOwner, namespace, podName)
+	}
+
+	switch ownerGroupKind(owner) {
+	case appsv1.SchemeGroupVersion.WithKind("ReplicaSet").GroupKind():
+		rs := &appsv1.ReplicaSet{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, rs); err != nil {
+			return "", "", fmt.Errorf("failed to get replicaset %s/%s: %w", namespace, owner.Name, err)
+		}
+		if rsOwner := metav1.GetControllerOf(rs); rsOwner != nil &&
+			ownerGroupKind(rsOwner) == appsv1.SchemeGroupVersion.WithKind("Deployment").GroupKind() {
+			return "Deployment", rsOwner.Name, nil
+		}
+		return "", "", fmt.Errorf("%w: replicaset %s/%s is not managed by a Deployment", ErrUnsupportedOwner, namespace, owner.Name)
+
+	case appsv1.SchemeGroupVersion.WithKind("StatefulSet").GroupKind():
+		return "StatefulSet", owner.Name, nil
+
+	case appsv1.SchemeGroupVersion.WithKind("DaemonSet").GroupKind():
+		return "DaemonSet", owner.Name, nil
+
+	case batchv1.SchemeGroupVersion.WithKind("Job").GroupKind():
+		job := &batchv1.Job{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: owner.Name}, job); err != nil {
+			return "", "", fmt.Errorf("failed to get job %s/%s: %w", namespace, owner.Name, err)
+		}
+		if jobOwner := metav1.GetControllerOf(job); jobOwner != nil &&
+			ownerGroupKind(jobOwner) == batchv1.SchemeGroupVersion.WithKind("CronJob").GroupKind() {
+			return "CronJob", jobOwner.Name, nil
+		}
+		return "Job", owner.Name, nil
+
+	default:
+		return "", "", fmt.Errorf("%w: pod %s/%s is owned by %s %s", ErrUnsupportedOwner, namespace, podName, owner.APIVersion, owner.Kind)
+	}
+}
+
+func ownerGroupKind(ref *metav1.OwnerReference) schema.GroupKind {
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return schema.GroupKind{Kind: ref.Kind}
+	}
+	return schema.GroupKind{Group: gv.Group, Kind: ref.Kind}
+}
@@ -0,0 +1,96 @@
+package remediate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func controllerRef(apiVersion, kind, name string) []metav1.OwnerReference {
+	isController := true
+	return []metav1.OwnerReference{{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		UID:        "uid-" + types.UID(name),
+		Controller: &isController,
+	}}
+}
+
+func objectMeta(name string, owners []metav1.OwnerReference) metav1.ObjectMeta {
+	return metav1.ObjectMeta{Name: name, Namespace: "prod", OwnerReferences: owners}
+}
+
+func TestResolvePodOwner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = batchv1.AddToScheme(scheme)
+
+	objs := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("api-5f7b8c9d", controllerRef("apps/v1", "Deployment", "api"))},
+		&corev1.Pod{ObjectMeta: objectMeta("api-5f7b8c9d-xyz", controllerRef("apps/v1", "ReplicaSet", "api-5f7b8c9d"))},
+		&corev1.Pod{ObjectMeta: objectMeta("db-0", controllerRef("apps/v1", "StatefulSet", "db"))},
+		&corev1.Pod{ObjectMeta: objectMeta("agent-abcde", controllerRef("apps/v1", "DaemonSet", "agent"))},
+		&batchv1.Job{ObjectMeta: objectMeta("backup-28000000", controllerRef("batch/v1", "CronJob", "backup"))},
+		&corev1.Pod{ObjectMeta: objectMeta("backup-28000000-abcde", controllerRef("batch/v1", "Job", "backup-28000000"))},
+		&batchv1.Job{ObjectMeta: objectMeta("migrate", nil)},
+		&corev1.Pod{ObjectMeta: objectMeta("migrate-abcde", controllerRef("batch/v1", "Job", "migrate"))},
+		&appsv1.ReplicaSet{ObjectMeta: objectMeta("bare-rs", nil)},
+		&corev1.Pod{ObjectMeta: objectMeta("bare-rs-abcde", controllerRef("apps/v1", "ReplicaSet", "bare-rs"))},
+		&corev1.Pod{ObjectMeta: objectMeta("rollout-abcde", controllerRef("argoproj.io/v1alpha1", "Rollout", "rollout"))},
+		&corev1.Pod{ObjectMeta: objectMeta("standalone", nil)},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+
+	tests := []struct {
+		name             string
+		pod              string
+		expectError      bool
+		expectErrUnknown bool
+		expectKind       string
+		expectName       string
+	}{
+		{name: "deployment via replicaset", pod: "api-5f7b8c9d-xyz", expectKind: "Deployment", expectName: "api"},
+		{name: "statefulset", pod: "db-0", expectKind: "StatefulSet", expectName: "db"},
+		{name: "daemonset", pod: "agent-abcde", expectKind: "DaemonSet", expectName: "agent"},
+		{name: "cronjob via job", pod: "backup-28000000-abcde", expectKind: "CronJob", expectName: "backup"},
+		{name: "standalone job", pod: "migrate-abcde", expectKind: "Job", expectName: "migrate"},
+		{name: "replicaset without deployment", pod: "bare-rs-abcde", expectError: true, expectErrUnknown: true},
+		{name: "unknown owner kind", pod: "rollout-abcde", expectError: true, expectErrUnknown: true},
+		{name: "pod without controller", pod: "standalone", expectError: true, expectErrUnknown: true},
+		{name: "pod not found", pod: "missing", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind, name, err := ResolvePodOwner(context.Background(), cl, "prod", tt.pod)
+
+			if tt.expectError {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if got := errors.Is(err, ErrUnsupportedOwner); got != tt.expectErrUnknown {
+					t.Errorf("expected ErrUnsupportedOwner=%v, got %v (%v)", tt.expectErrUnknown, got, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if kind != tt.expectKind || name != tt.expectName {
+				t.Errorf("expected %s/%s, got %s/%s", tt.expectKind, tt.expectName, kind, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParamSourcesKey is the action param recording where each effective param
+// came from, e.g. "maxMemory=annotation,memoryIncreasePercent=route"
+const ParamSourcesKey = "paramSources"
+
+const (
+	// ParamSourceRoute marks a param set by the routing rule
+	ParamSourceRoute = "route"
+
+	// ParamSourceAnnotation marks a param overridden by a workload annotation
+	ParamSourceAnnotation = "annotation"
+)
+
+// ParamAnnotations maps workload annotations to the action params they override
+var ParamAnnotations = map[string]string{
+	"heal8s.io/max-memory":              "maxMemory",
+	"heal8s.io/memory-increase-percent": "memoryIncreasePercent",
+	"heal8s.io/max-cpu":                 "maxCPU",
+	"heal8s.io/cpu-increase-percent":    "cpuIncreasePercent",
+	"heal8s.io/max-replicas":            "maxReplicas",
+	"heal8s.io/scale-up-percent":        "scaleUpPercent",
+	"heal8s.io/restart-cooldown":        "restartCooldown",
+}
+
+// MergeParamOverrides returns params with the workload's heal8s.io param
+// annotations merged over them, plus a ParamSourcesKey entry recording the
+// source of each param. An invalid annotation value is an error.
+func MergeParamOverrides(params, annotations map[string]string) (map[string]string, error) {
+	merged := make(map[string]string, len(params)+1)
+	sources := make(map[string]string, len(params))
+	for key, value := range params {
+		if key == ParamSourcesKey {
+			continue
+		}
+		merged[key] = value
+		sources[key] = ParamSourceRoute
+	}
+
+	for annotation, param := range ParamAnnotations {
+		value, ok := annotations[annotation]
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if err := ValidateParams(map[string]string{param: value}); err != nil {
+			return nil, fmt.Errorf("invalid %s annotation: %w", annotation, err)
+		}
+		merged[param] = value
+		sources[param] = ParamSourceAnnotation
+	}
+
+	if len(sources) > 0 {
+		keys := make([]string, 0, len(sources))
+		for key := range sources {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		entries := make([]string, 0, len(keys))
+		for _, key := range keys {
+			entries = append(entries, key+"="+sources[key])
+		}
+		merged[ParamSourcesKey] = strings.Join(entries, ",")
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,78 @@
+package remediate
+
+import (
+	"testing"
+)
+
+func TestMergeParamOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]string
+		annotations map[string]string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:   "route params only",
+			params: map[string]string{"maxMemory": "2Gi", "memoryIncreasePercent": "25"},
+			expected: map[string]string{
+				"maxMemory":             "2Gi",
+				"memoryIncreasePercent": "25",
+				ParamSourcesKey:         "maxMemory=route,memoryIncreasePercent=route",
+			},
+		},
+		{
+			name:   "annotation overrides route",
+			params: map[string]string{"maxMemory": "2Gi", "memoryIncreasePercent": "25"},
+			annotations: map[string]string{
+				"heal8s.io/max-memory":   "6Gi",
+				"heal8s.io/max-replicas": " 12 ",
+				"unrelated":              "x",
+			},
+			expected: map[string]string{
+				"maxMemory":             "6Gi",
+				"memoryIncreasePercent": "25",
+				"maxReplicas":           "12",
+				ParamSourcesKey:         "maxMemory=annotation,maxReplicas=annotation,memoryIncreasePercent=route",
+			},
+		},
+		{
+			name:        "invalid quantity",
+			params:      map[string]string{"maxMemory": "2Gi"},
+			annotations: map[string]string{"heal8s.io/max-memory": "six gigs"},
+			expectError: true,
+		},
+		{
+			name:        "non-positive percent",
+			annotations: map[string]string{"heal8s.io/memory-increase-percent": "0"},
+			expectError: true,
+		},
+		{
+			name:     "no params",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeParamOverrides(tt.params, tt.annotations)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(merged) != len(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, merged)
+			}
+			for key, value := range tt.expected {
+				if merged[key] != value {
+					t.Errorf("param %s: expected %q, got %q", key, value, merged[key])
+				}
+			}
+		})
+	}
+}
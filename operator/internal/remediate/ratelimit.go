@@ -0,0 +1,175 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"time"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+// AnnotationThrottled marks a Remediation created over budget. Its value is
+// the reason; the controller moves such Remediations to Throttled instead of
+// processing them.
+const AnnotationThrottled = "heal8s.io/throttled"
+
+// OnLimit is what happens to an alert that is over budget
+type OnLimit string
+
+const (
+	// OnLimitThrottle creates the Remediation in the Throttled phase
+	OnLimitThrottle OnLimit = "Throttle"
+
+	// OnLimitSkip creates nothing; the alert is only counted as skipped
+	OnLimitSkip OnLimit = "Skip"
+)
+
+// Budget allows at most Max remediations per Window. A zero Max disables it.
+type Budget struct {
+	Max    int
+	Window time.Duration
+}
+
+func (b Budget) validate(name string) error {
+	if b.Max < 0 {
+		return fmt.Errorf("%s.max must not be negative", name)
+	}
+	if b.Max > 0 && b.Window <= 0 {
+		return fmt.Errorf("%s.window must be positive", name)
+	}
+	return nil
+}
+
+// RateLimits bounds how often heal8s changes workloads
+type RateLimits struct {
+	// Cooldown is how long after a remediation was applied to a target no
+	// further remediation with the same action is made
+	Cooldown time.Duration
+
+	// PerNamespace limits remediations per target namespace
+	PerNamespace Budget
+
+	// Global limits remediations across all namespaces
+	Global Budget
+
+	// OnLimit chooses between recording a Throttled Remediation and skipping
+	// the alert. Defaults to OnLimitThrottle.
+	OnLimit OnLimit
+}
+
+// Enabled reports whether any limit is configured
+func (l RateLimits) Enabled() bool {
+	return l.Cooldown > 0 || l.PerNamespace.Max > 0 || l.Global.Max > 0
+}
+
+// Validate checks the limits
+func (l RateLimits) Validate() error {
+	if l.Cooldown < 0 {
+		return fmt.Errorf("cooldown must not be negative")
+	}
+	if err := l.PerNamespace.validate("perNamespace"); err != nil {
+		return err
+	}
+	if err := l.Global.validate("global"); err != nil {
+		return err
+	}
+	switch l.OnLimit {
+	case "", OnLimitThrottle, OnLimitSkip:
+	default:
+		return fmt.Errorf("onLimit must be %s or %s, got %q", OnLimitThrottle, OnLimitSkip, l.OnLimit)
+	}
+	return nil
+}
+
+// Check decides whether a new remediation for spec fits the limits, given
+// the existing Remediations. It returns the reason if it does not.
+func (l RateLimits) Check(spec *k8shealerv1alpha1.RemediationSpec, existing []k8shealerv1alpha1.Remediation, now time.Time) string {
+	namespaceCount, globalCount := 0, 0
+	for i := range existing {
+		item := &existing[i]
+
+		if l.Cooldown > 0 && sameTargetAndAction(&item.Spec, spec) {
+			if applied := appliedTime(item); applied != nil && now.Sub(*applied) < l.Cooldown {
+				return fmt.Sprintf("%s %s/%s had %s applied at %s; cooldown is %s",
+					spec.Target.Kind, spec.Target.Namespace, spec.Target.Name, spec.Action.Type,
+					applied.UTC().Format(time.RFC3339), l.Cooldown)
+			}
+		}
+
+		if !countsTowardsBudget(item) {
+			continue
+		}
+		// An object without a creation time has not been persisted yet
+		var age time.Duration
+		if !item.CreationTimestamp.IsZero() {
+			age = now.Sub(item.CreationTimestamp.Time)
+		}
+		if l.Global.Max > 0 && age < l.Global.Window {
+			globalCount++
+		}
+		if l.PerNamespace.Max > 0 && age < l.PerNamespace.Window && item.Spec.Target.Namespace == spec.Target.Namespace {
+			namespaceCount++
+		}
+	}
+
+	if l.PerNamespace.Max > 0 && namespaceCount >= l.PerNamespace.Max {
+		return fmt.Sprintf("namespace %s reached its limit of %d remediations per %s",
+			spec.Target.Namespace, l.PerNamespace.Max, l.PerNamespace.Window)
+	}
+	if l.Global.Max > 0 && globalCount >= l.Global.Max {
+		return fmt.Sprintf("reached the global limit of %d remediations per %s", l.Global.Max, l.Global.Window)
+	}
+	return ""
+}
+
+func sameTargetAndAction(a, b *k8shealerv1alpha1.RemediationSpec) bool {
+	return a.Target.Namespace == b.Target.Namespace &&
+		a.Target.Kind == b.Target.Kind &&
+		a.Target.Name == b.Target.Name &&
+		a.Target.Container == b.Target.Container &&
+		a.Action.Type == b.Action.Type
+}
+
+// appliedTime returns when remediation changed its target, or nil if it has not
+func appliedTime(remediation *k8shealerv1alpha1.Remediation) *time.Time {
+	if remediation.Status.AppliedAt != nil {
+		return &remediation.Status.AppliedAt.Time
+	}
+	if remediation.Status.Phase == k8shealerv1alpha1.RemediationPhaseSucceeded && remediation.Status.ResolvedAt != nil {
+		return &remediation.Status.ResolvedAt.Time
+	}
+	return nil
+}
+
+// countsTowardsBudget reports whether remediation used up budget. Throttled
+// and cancelled Remediations never changed anything.
+func countsTowardsBudget(remediation *k8shealerv1alpha1.Remediation) bool {
+	if IsThrottled(remediation) {
+		return false
+	}
+	return remediation.Status.Phase != k8shealerv1alpha1.RemediationPhaseCancelled
+}
+
+// IsThrottled reports whether remediation was created over budget
+func IsThrottled(remediation *k8shealerv1alpha1.Remediation) bool {
+	if remediation.Status.Phase == k8shealerv1alpha1.RemediationPhaseThrottled {
+		return true
+	}
+	_, ok := remediation.Annotations[AnnotationThrottled]
+	return ok
+}
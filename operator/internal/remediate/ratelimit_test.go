@@ -0,0 +1,135 @@
+package remediate
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestRateLimits_Check(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) metav1.Time { return metav1.NewTime(now.Add(-d)) }
+
+	remediation := func(namespace, name string, created time.Duration, phase k8shealerv1alpha1.RemediationPhase) k8shealerv1alpha1.Remediation {
+		return k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: ago(created)},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: name, Namespace: namespace, Container: "app"},
+				Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: phase},
+		}
+	}
+	applied := func(r k8shealerv1alpha1.Remediation, d time.Duration) k8shealerv1alpha1.Remediation {
+		at := ago(d)
+		r.Status.AppliedAt = &at
+		return r
+	}
+	throttled := remediation("prod", "web", time.Minute, "")
+	throttled.Annotations = map[string]string{AnnotationThrottled: "over budget"}
+
+	candidate := remediation("prod", "api", 0, "").Spec
+
+	tests := []struct {
+		name         string
+		limits       RateLimits
+		existing     []k8shealerv1alpha1.Remediation
+		expectReason string
+	}{
+		{
+			name:     "no limits",
+			existing: []k8shealerv1alpha1.Remediation{applied(remediation("prod", "api", time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded), time.Minute)},
+		},
+		{
+			name:         "in cooldown",
+			limits:       RateLimits{Cooldown: time.Hour},
+			existing:     []k8shealerv1alpha1.Remediation{applied(remediation("prod", "api", 2*time.Hour, k8shealerv1alpha1.RemediationPhaseSucceeded), 30*time.Minute)},
+			expectReason: "cooldown is 1h0m0s",
+		},
+		{
+			name:     "cooldown over",
+			limits:   RateLimits{Cooldown: time.Hour},
+			existing: []k8shealerv1alpha1.Remediation{applied(remediation("prod", "api", 2*time.Hour, k8shealerv1alpha1.RemediationPhaseSucceeded), 90*time.Minute)},
+		},
+		{
+			name:     "cooldown ignores other targets",
+			limits:   RateLimits{Cooldown: time.Hour},
+			existing: []k8shealerv1alpha1.Remediation{applied(remediation("prod", "web", time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded), time.Minute)},
+		},
+		{
+			name:     "cooldown ignores unapplied remediations",
+			limits:   RateLimits{Cooldown: time.Hour},
+			existing: []k8shealerv1alpha1.Remediation{remediation("prod", "api", time.Minute, k8shealerv1alpha1.RemediationPhaseFailed)},
+		},
+		{
+			name:   "namespace budget used up",
+			limits: RateLimits{PerNamespace: Budget{Max: 2, Window: time.Hour}},
+			existing: []k8shealerv1alpha1.Remediation{
+				remediation("prod", "web", 10*time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded),
+				remediation("prod", "worker", 20*time.Minute, k8shealerv1alpha1.RemediationPhaseFailed),
+			},
+			expectReason: "namespace prod reached its limit of 2 remediations per 1h0m0s",
+		},
+		{
+			name:   "namespace budget ignores old, other and throttled remediations",
+			limits: RateLimits{PerNamespace: Budget{Max: 2, Window: time.Hour}},
+			existing: []k8shealerv1alpha1.Remediation{
+				remediation("prod", "web", 2*time.Hour, k8shealerv1alpha1.RemediationPhaseSucceeded),
+				remediation("dev", "web", time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded),
+				remediation("prod", "web", time.Minute, k8shealerv1alpha1.RemediationPhaseThrottled),
+				remediation("prod", "web", time.Minute, k8shealerv1alpha1.RemediationPhaseCancelled),
+				throttled,
+				remediation("prod", "worker", time.Minute, k8shealerv1alpha1.RemediationPhasePending),
+			},
+		},
+		{
+			name:   "global budget used up",
+			limits: RateLimits{Global: Budget{Max: 2, Window: time.Hour}},
+			existing: []k8shealerv1alpha1.Remediation{
+				remediation("dev", "web", time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded),
+				remediation("staging", "web", time.Minute, ""),
+			},
+			expectReason: "global limit of 2 remediations per 1h0m0s",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := tt.limits.Check(&candidate, tt.existing, now)
+			if tt.expectReason == "" && reason != "" {
+				t.Errorf("expected no throttling, got %q", reason)
+			}
+			if tt.expectReason != "" && !strings.Contains(reason, tt.expectReason) {
+				t.Errorf("expected reason containing %q, got %q", tt.expectReason, reason)
+			}
+		})
+	}
+}
+
+func TestRateLimits_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		limits      RateLimits
+		expectError bool
+	}{
+		{name: "empty", limits: RateLimits{}},
+		{name: "valid", limits: RateLimits{Cooldown: time.Hour, Global: Budget{Max: 5, Window: time.Hour}, OnLimit: OnLimitSkip}},
+		{name: "negative cooldown", limits: RateLimits{Cooldown: -time.Second}, expectError: true},
+		{name: "negative max", limits: RateLimits{PerNamespace: Budget{Max: -1}}, expectError: true},
+		{name: "missing window", limits: RateLimits{Global: Budget{Max: 5}}, expectError: true},
+		{name: "unknown onLimit", limits: RateLimits{OnLimit: "Drop"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.limits.Validate()
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error: %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// AnnotationRestartedAt is the pod template annotation kubectl rollout
+	// restart sets; changing it rolls the workload's pods
+	AnnotationRestartedAt = "kubectl.kubernetes.io/restartedAt"
+
+	// AnnotationLastRestart records on the workload when heal8s last
+	// restarted it or one of its pods
+	AnnotationLastRestart = "heal8s.io/last-restart"
+)
+
+const (
+	// RestartScopeWorkload rolls all pods of the workload
+	RestartScopeWorkload = "Workload"
+
+	// RestartScopePod deletes only the pod named in the alert
+	RestartScopePod = "Pod"
+)
+
+// DefaultRestartCooldown is how long after a restart another one is refused
+const DefaultRestartCooldown = 30 * time.Minute
+
+// ErrRestartCooldown is returned by ApplyRestartPods when the workload was
+// restarted within the cooldown
+var ErrRestartCooldown = errors.New("restart cooldown")
+
+// RestartScope returns the restartScope param, RestartScopeWorkload by default
+func RestartScope(params map[string]string) string {
+	if scope := params["restartScope"]; scope != "" {
+		return scope
+	}
+	return RestartScopeWorkload
+}
+
+// ApplyRestartPods marks a workload as restarted at now. With the Workload
+// scope it also sets the restartedAt pod template annotation, which rolls the
+// pods like kubectl rollout restart; with the Pod scope deleting the pod is
+// left to the caller. A restart within restartCooldown of the last one, by
+// heal8s or kubectl, returns an error wrapping ErrRestartCooldown.
+func ApplyRestartPods(obj client.Object, params map[string]string, now time.Time) error {
+	cooldown := DefaultRestartCooldown
+	if c, ok := params["restartCooldown"]; ok {
+		if val, err := time.ParseDuration(c); err == nil {
+			cooldown = val
+		}
+	}
+
+	var template *corev1.PodTemplateSpec
+	switch v := obj.(type) {
+	case *appsv1.Deployment:
+		template = &v.Spec.Template
+	case *appsv1.StatefulSet:
+		template = &v.Spec.Template
+	case *appsv1.DaemonSet:
+		template = &v.Spec.Template
+	default:
+		return fmt.Errorf("unsupported object type for restart: %T", obj)
+	}
+
+	if last := lastRestart(obj, template); !last.IsZero() && now.Sub(last) < cooldown {
+		return fmt.Errorf("%w: last restarted at %s, cooldown is %s", ErrRestartCooldown, last.UTC().Format(time.RFC3339), cooldown)
+	}
+
+	stamp := now.UTC().Format(time.RFC3339)
+	if RestartScope(params) == RestartScopeWorkload {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[AnnotationRestartedAt] = stamp
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationLastRestart] = stamp
+	obj.SetAnnotations(annotations)
+
+	return nil
+}
+
+// lastRestart returns the latest restart recorded on the workload or its pod
+// template; zero if there is none
+func lastRestart(obj client.Object, template *corev1.PodTemplateSpec) time.Time {
+	var last time.Time
+	for _, value := range []string{obj.GetAnnotations()[AnnotationLastRestart], template.Annotations[AnnotationRestartedAt]} {
+		if value == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil && t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
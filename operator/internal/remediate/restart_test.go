@@ -0,0 +1,112 @@
+package remediate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestApplyRestartPods(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stamp := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+
+	deployment := func(annotations, templateAnnotations map[string]string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "api", Annotations: annotations},
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Annotations: templateAnnotations}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		obj              client.Object
+		params           map[string]string
+		expectError      bool
+		expectCooldown   bool
+		expectRestartsAt bool
+	}{
+		{
+			name:             "rolls a deployment",
+			obj:              deployment(nil, nil),
+			expectRestartsAt: true,
+		},
+		{
+			name:             "rolls a statefulset",
+			obj:              &appsv1.StatefulSet{},
+			expectRestartsAt: true,
+		},
+		{
+			name:             "rolls a daemonset",
+			obj:              &appsv1.DaemonSet{},
+			expectRestartsAt: true,
+		},
+		{
+			name:   "pod scope only records the restart",
+			obj:    deployment(nil, nil),
+			params: map[string]string{"restartScope": RestartScopePod},
+		},
+		{
+			name:           "heal8s restart within cooldown",
+			obj:            deployment(map[string]string{AnnotationLastRestart: stamp(10 * time.Minute)}, nil),
+			expectError:    true,
+			expectCooldown: true,
+		},
+		{
+			name:           "kubectl rollout restart within cooldown",
+			obj:            deployment(nil, map[string]string{AnnotationRestartedAt: stamp(5 * time.Minute)}),
+			params:         map[string]string{"restartCooldown": "1h"},
+			expectError:    true,
+			expectCooldown: true,
+		},
+		{
+			name:             "restart after cooldown",
+			obj:              deployment(map[string]string{AnnotationLastRestart: stamp(10 * time.Minute)}, nil),
+			params:           map[string]string{"restartCooldown": "5m"},
+			expectRestartsAt: true,
+		},
+		{
+			name:        "unsupported kind",
+			obj:         &batchv1.Job{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ApplyRestartPods(tt.obj, tt.params, now)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error: %v, got: %v", tt.expectError, err)
+			}
+			if errors.Is(err, ErrRestartCooldown) != tt.expectCooldown {
+				t.Errorf("expected cooldown error: %v, got: %v", tt.expectCooldown, err)
+			}
+			if tt.expectError {
+				return
+			}
+
+			if got := tt.obj.GetAnnotations()[AnnotationLastRestart]; got != now.Format(time.RFC3339) {
+				t.Errorf("expected %s=%s, got %q", AnnotationLastRestart, now.Format(time.RFC3339), got)
+			}
+			var template corev1.PodTemplateSpec
+			switch v := tt.obj.(type) {
+			case *appsv1.Deployment:
+				template = v.Spec.Template
+			case *appsv1.StatefulSet:
+				template = v.Spec.Template
+			case *appsv1.DaemonSet:
+				template = v.Spec.Template
+			}
+			if _, ok := template.Annotations[AnnotationRestartedAt]; ok != tt.expectRestartsAt {
+				t.Errorf("expected restartedAt annotation: %v, got %v", tt.expectRestartsAt, template.Annotations)
+			}
+		})
+	}
+}
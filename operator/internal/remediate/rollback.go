@@ -18,66 +18,352 @@ package remediate
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const (
+	// DefaultRollbackMaxRevisions is how many past revisions are considered
+	DefaultRollbackMaxRevisions = 5
+
+	// DefaultRollbackStabilityPeriod is how long a revision must have been
+	// current before it counts as stable
+	DefaultRollbackStabilityPeriod = 10 * time.Minute
+
+	// annotationDeploymentRevision is set by the Deployment controller on
+	// Deployments and their ReplicaSets
+	annotationDeploymentRevision = "deployment.kubernetes.io/revision"
+
+	// annotationDeploymentRevisionHistory lists the earlier revisions of a
+	// ReplicaSet that a rollback reused
+	annotationDeploymentRevisionHistory = "deployment.kubernetes.io/revision-history"
 )
 
-// ApplyRollbackImage rolls back a workload to the previous stable image
-func ApplyRollbackImage(ctx context.Context, cl client.Client, obj client.Object, params map[string]string) error {
-	maxRevisions := 5 // default
+// ErrNoStableRevision is returned by ApplyRollbackImage when the workload's
+// history has no revision to roll back to
+var ErrNoStableRevision = errors.New("no stable revision found")
+
+// revision is one entry of a workload's rollout history
+type revision struct {
+	number int64
+	source string
+	// since is when the revision became current, zero if unknown. A
+	// ReplicaSet or ControllerRevision reused by a rollback keeps its
+	// creation time under a new number, so only a first use is known.
+	since time.Time
+	// earlier are the numbers the same template had before a reuse, with
+	// when they became current where known
+	earlier  map[int64]time.Time
+	template corev1.PodTemplateSpec
+}
+
+// ApplyRollbackImage restores container images from the newest earlier
+// revision of a workload that stayed current for at least the
+// rollbackStabilityPeriod param before the next rollout replaced it. Only the
+// rollbackMaxRevisions revisions before the current one are considered.
+// History comes from ReplicaSets for Deployments and ControllerRevisions for
+// StatefulSets and DaemonSets; without a usable revision the newest images
+// recorded as stable on the workload are restored instead.
+//
+// If containerName is set only that container is rolled back, otherwise
+// every container; init containers whose image changed are rolled back too.
+// The replaced images are recorded in the heal8s.io/attempted-images
+// annotation, and are skipped by later rollbacks until they are recorded as
+// stable. It returns what was restored.
+func ApplyRollbackImage(ctx context.Context, cl client.Client, obj client.Object, containerName string, params map[string]string) (*k8shealerv1alpha1.RollbackStatus, error) {
+	maxRevisions := DefaultRollbackMaxRevisions
 	if m, ok := params["rollbackMaxRevisions"]; ok {
 		if val, err := strconv.Atoi(m); err == nil {
 			maxRevisions = val
 		}
 	}
 
+	stabilityPeriod := DefaultRollbackStabilityPeriod
+	if s, ok := params["rollbackStabilityPeriod"]; ok {
+		if val, err := time.ParseDuration(s); err == nil {
+			stabilityPeriod = val
+		}
+	}
+
+	var template *corev1.PodTemplateSpec
+	var history []revision
+	var err error
 	switch v := obj.(type) {
 	case *appsv1.Deployment:
-		return rollbackDeployment(ctx, cl, v, maxRevisions)
+		template = &v.Spec.Template
+		history, err = deploymentHistory(ctx, cl, v)
+	case *appsv1.StatefulSet:
+		template = &v.Spec.Template
+		history, err = controllerRevisionHistory(ctx, cl, v, v.Spec.Selector)
+	case *appsv1.DaemonSet:
+		template = &v.Spec.Template
+		history, err = controllerRevisionHistory(ctx, cl, v, v.Spec.Selector)
 	default:
-		return fmt.Errorf("rollback not supported for type: %T", obj)
+		return nil, fmt.Errorf("rollback not supported for type: %T", obj)
+	}
+	if err != nil {
+		return nil, err
 	}
-}
 
-func rollbackDeployment(ctx context.Context, cl client.Client, deployment *appsv1.Deployment, maxRevisions int) error {
-	// Get the annotation for the last stable image
-	lastStableImage := deployment.Annotations["heal8s.io/last-stable-image"]
+	if containerName != "" {
+		if _, ok := TemplateImages(template).Containers[containerName]; !ok {
+			return nil, fmt.Errorf("container %s not found", containerName)
+		}
+	}
 
-	if lastStableImage == "" {
-		// Try to get previous revision from ReplicaSets
-		// This is a simplified approach - in production you'd query ReplicaSets
-		// and find the previous stable version
-		return fmt.Errorf("no previous stable image found (annotation heal8s.io/last-stable-image missing)")
+	var bad ImageSet
+	if attempted, err := AttemptedImages(obj); err != nil {
+		return nil, err
+	} else if attempted != nil {
+		bad = *attempted
 	}
 
-	// Find the container and update its image
-	for i := range deployment.Spec.Template.Spec.Containers {
-		container := &deployment.Spec.Template.Spec.Containers[i]
-		// Store current image as "attempted" image
-		if deployment.Annotations == nil {
-			deployment.Annotations = make(map[string]string)
+	status := &k8shealerv1alpha1.RollbackStatus{}
+	var changes ImageSet
+	if chosen := findStableRevision(history, template, containerName, bad, maxRevisions, stabilityPeriod); chosen != nil {
+		changes = imageChanges(template, TemplateImages(&chosen.template), containerName)
+		status.Revision = chosen.number
+		status.Source = chosen.source
+	} else {
+		// Fall back to images recorded as stable on the workload
+		var source string
+		changes, source, err = findStableImages(obj, template, containerName, bad)
+		if err != nil {
+			return nil, err
+		}
+		if changes.IsEmpty() {
+			return nil, fmt.Errorf("%w within the last %d revisions stable for %s", ErrNoStableRevision, maxRevisions, stabilityPeriod)
 		}
-		deployment.Annotations["heal8s.io/attempted-image"] = container.Image
+		status.Source = "annotation " + source
+	}
 
-		// Rollback to last stable
-		container.Image = lastStableImage
+	replaced := setImages(template, changes)
+	if err := setImageSetAnnotation(obj, AnnotationAttemptedImages, replaced); err != nil {
+		return nil, err
 	}
 
+	status.Images = changes.Containers
+	status.InitImages = changes.InitContainers
+	return status, nil
+}
+
+// findStableRevision returns the newest revision before the current one, at
+// most maxRevisions back, that was current for at least stabilityPeriod and
+// whose images of the rolled back containers differ from template's and are
+// not in bad. Revisions whose time as current cannot be measured are skipped.
+func findStableRevision(history []revision, template *corev1.PodTemplateSpec, containerName string, bad ImageSet, maxRevisions int, stabilityPeriod time.Duration) *revision {
+	sort.Slice(history, func(i, j int) bool { return history[i].number > history[j].number })
+	starts := revisionStarts(history)
+
+	// history[0] is the current revision
+	for i := 1; i < len(history) && i <= maxRevisions; i++ {
+		candidate := &history[i]
+		// A revision was current until the next revision number was rolled out
+		start, end := starts[candidate.number], starts[candidate.number+1]
+		if start.IsZero() || end.IsZero() || end.Sub(start) < stabilityPeriod {
+			continue
+		}
+		changes := imageChanges(template, TemplateImages(&candidate.template), containerName)
+		if changes.IsEmpty() || knownBad(changes, bad) {
+			continue
+		}
+		return candidate
+	}
 	return nil
 }
 
-// MarkImageAsStable marks the current image as stable (to be called after successful deployment)
-func MarkImageAsStable(deployment *appsv1.Deployment) {
-	if deployment.Annotations == nil {
-		deployment.Annotations = make(map[string]string)
+// revisionStarts maps revision numbers to when they became current, for those
+// where that is known. A start earlier than that of a lower number means the
+// object was reused without a trace, so it is dropped.
+func revisionStarts(history []revision) map[int64]time.Time {
+	starts := map[int64]time.Time{}
+	for _, r := range history {
+		for number, since := range r.earlier {
+			starts[number] = since
+		}
+		starts[r.number] = r.since
+	}
+
+	numbers := make([]int64, 0, len(starts))
+	for number := range starts {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	var latest time.Time
+	for _, number := range numbers {
+		since := starts[number]
+		if since.IsZero() || since.Before(latest) {
+			delete(starts, number)
+			continue
+		}
+		latest = since
+	}
+	return starts
+}
+
+// findStableImages returns the images of the newest stable image set recorded
+// on a workload that differ from template's and are not in bad, and the
+// annotation they came from. The heal8s.io/stable-images annotation is tried
+// first, then the older sets in the stable-images history.
+func findStableImages(obj client.Object, template *corev1.PodTemplateSpec, containerName string, bad ImageSet) (ImageSet, string, error) {
+	stable, err := StableImages(obj, template, containerName)
+	if err != nil {
+		return ImageSet{}, "", err
+	}
+	if stable != nil {
+		if changes := imageChanges(template, *stable, containerName); !changes.IsEmpty() && !knownBad(changes, bad) {
+			if obj.GetAnnotations()[AnnotationStableImages] == "" {
+				return changes, AnnotationLastStableImage, nil
+			}
+			return changes, AnnotationStableImages, nil
+		}
+	}
+
+	history, err := StableImagesHistory(obj)
+	if err != nil {
+		return ImageSet{}, "", err
+	}
+	for _, images := range history {
+		if changes := imageChanges(template, images, containerName); !changes.IsEmpty() && !knownBad(changes, bad) {
+			return changes, AnnotationStableImagesHistory, nil
+		}
+	}
+	return ImageSet{}, "", nil
+}
+
+// deploymentHistory returns the revisions recorded in a Deployment's ReplicaSets
+func deploymentHistory(ctx context.Context, cl client.Client, deployment *appsv1.Deployment) ([]revision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
 	}
 
-	// Store the first container's image as stable
-	if len(deployment.Spec.Template.Spec.Containers) > 0 {
-		currentImage := deployment.Spec.Template.Spec.Containers[0].Image
-		deployment.Annotations["heal8s.io/last-stable-image"] = currentImage
+	list := &appsv1.ReplicaSetList{}
+	if err := cl.List(ctx, list, client.InNamespace(deployment.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list ReplicaSets: %w", err)
+	}
+
+	var history []revision
+	for i := range list.Items {
+		rs := &list.Items[i]
+		if !metav1.IsControlledBy(rs, deployment) {
+			continue
+		}
+		number, err := strconv.ParseInt(rs.Annotations[annotationDeploymentRevision], 10, 64)
+		if err != nil {
+			continue
+		}
+		entry := revision{
+			number:   number,
+			source:   "ReplicaSet " + rs.Name,
+			since:    rs.CreationTimestamp.Time,
+			template: rs.Spec.Template,
+		}
+		// A reused ReplicaSet was created for the first of its revisions
+		if earlier := reusedRevisions(rs.Annotations[annotationDeploymentRevisionHistory]); len(earlier) > 0 {
+			entry.earlier = map[int64]time.Time{earlier[0]: entry.since}
+			entry.since = time.Time{}
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// controllerRevisionHistory returns the revisions recorded in the
+// ControllerRevisions of a StatefulSet or DaemonSet
+func controllerRevisionHistory(ctx context.Context, cl client.Client, owner client.Object, labelSelector *metav1.LabelSelector) ([]revision, error) {
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %w", err)
+	}
+
+	list := &appsv1.ControllerRevisionList{}
+	if err := cl.List(ctx, list, client.InNamespace(owner.GetNamespace()), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, fmt.Errorf("failed to list ControllerRevisions: %w", err)
+	}
+
+	var history []revision
+	for i := range list.Items {
+		cr := &list.Items[i]
+		if !metav1.IsControlledBy(cr, owner) {
+			continue
+		}
+		template, err := controllerRevisionTemplate(cr)
+		if err != nil {
+			return nil, err
+		}
+		entry := revision{
+			number:   cr.Revision,
+			source:   "ControllerRevision " + cr.Name,
+			since:    cr.CreationTimestamp.Time,
+			template: *template,
+		}
+		// ControllerRevisions are immutable except for the number a reuse
+		// bumps, which leaves no other trace
+		if updatedAfterCreation(cr) {
+			entry.since = time.Time{}
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// reusedRevisions parses a revision-history annotation into ascending
+// revision numbers
+func reusedRevisions(annotation string) []int64 {
+	var numbers []int64
+	for _, field := range strings.Split(annotation, ",") {
+		if number, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64); err == nil {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+	return numbers
+}
+
+// updatedAfterCreation reports whether obj's managed fields record a write
+// after it was created
+func updatedAfterCreation(obj metav1.Object) bool {
+	created := obj.GetCreationTimestamp().Time
+	for _, entry := range obj.GetManagedFields() {
+		if entry.Time != nil && entry.Time.Time.Sub(created) > time.Second {
+			return true
+		}
+	}
+	return false
+}
+
+// controllerRevisionTemplate decodes the pod template stored in a
+// ControllerRevision, a patch of the form {"spec":{"template":{...}}}
+func controllerRevisionTemplate(cr *appsv1.ControllerRevision) (*corev1.PodTemplateSpec, error) {
+	raw := cr.Data.Raw
+	if raw == nil && cr.Data.Object != nil {
+		var err error
+		if raw, err = json.Marshal(cr.Data.Object); err != nil {
+			return nil, fmt.Errorf("failed to encode ControllerRevision %s: %w", cr.Name, err)
+		}
+	}
+
+	var data struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, fmt.Errorf("failed to decode ControllerRevision %s: %w", cr.Name, err)
 	}
+	return &data.Spec.Template, nil
 }
@@ -0,0 +1,513 @@
+package remediate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// rolloutHistory describes revisions by the image of the app container and
+// how long after the first one each was rolled out
+type rolloutHistory []struct {
+	image string
+	at    time.Duration
+}
+
+func podTemplate(appImage string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{
+			{Name: "app", Image: appImage},
+			{Name: "proxy", Image: "envoy:1.29"},
+		}},
+	}
+}
+
+// deploymentWithHistory returns a Deployment running the last revision of
+// history and a ReplicaSet for each revision
+func deploymentWithHistory(history rolloutHistory) (*appsv1.Deployment, []client.Object) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	isController := true
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "api",
+			Namespace:   "default",
+			UID:         types.UID("uid-api"),
+			Annotations: map[string]string{annotationDeploymentRevision: strconv.Itoa(len(history))},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: podTemplate(history[len(history)-1].image),
+		},
+	}
+
+	objects := []client.Object{deployment}
+	for i, rev := range history {
+		objects = append(objects, &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("api-%d", i+1),
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "api"},
+				Annotations:       map[string]string{annotationDeploymentRevision: strconv.Itoa(i + 1)},
+				CreationTimestamp: metav1.NewTime(start.Add(rev.at)),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: deployment.UID, Controller: &isController,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: podTemplate(rev.image)},
+		})
+	}
+	// Not owned by the Deployment, so never a candidate
+	objects = append(objects, &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "api-orphan",
+			Namespace:         "default",
+			Labels:            map[string]string{"app": "api"},
+			Annotations:       map[string]string{annotationDeploymentRevision: "99"},
+			CreationTimestamp: metav1.NewTime(start),
+		},
+		Spec: appsv1.ReplicaSetSpec{Template: podTemplate("api:orphan")},
+	})
+	return deployment, objects
+}
+
+func TestApplyRollbackImage_Deployment(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		history        rolloutHistory
+		container      string
+		params         map[string]string
+		annotations    map[string]string
+		expectError    bool
+		expectRevision int64
+		expectImage    string
+	}{
+		{
+			name:           "previous revision was stable",
+			history:        rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v3", 3 * time.Hour}},
+			expectRevision: 2,
+			expectImage:    "api:v2",
+		},
+		{
+			name:           "skips a revision replaced within the stability period",
+			history:        rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v3", 2*time.Hour + 5*time.Minute}},
+			expectRevision: 1,
+			expectImage:    "api:v1",
+		},
+		{
+			name:           "custom stability period",
+			history:        rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v3", 2*time.Hour + 5*time.Minute}},
+			params:         map[string]string{"rollbackStabilityPeriod": "1m"},
+			expectRevision: 2,
+			expectImage:    "api:v2",
+		},
+		{
+			name:           "skips a revision with the same images",
+			history:        rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v2", 4 * time.Hour}},
+			expectRevision: 1,
+			expectImage:    "api:v1",
+		},
+		{
+			name:        "stable revision beyond rollbackMaxRevisions",
+			history:     rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v3", 2*time.Hour + time.Minute}},
+			params:      map[string]string{"rollbackMaxRevisions": "1"},
+			expectError: true,
+		},
+		{
+			name:        "no earlier revision",
+			history:     rolloutHistory{{"api:v1", 0}},
+			expectError: true,
+		},
+		{
+			name:        "falls back to the stable-images annotation",
+			history:     rolloutHistory{{"api:v1", 0}},
+			annotations: map[string]string{AnnotationStableImages: `{"containers":{"app":"api:v0","proxy":"envoy:1.29"}}`},
+			expectImage: "api:v0",
+		},
+		{
+			name:        "falls back to the last-stable-image annotation for the target container",
+			history:     rolloutHistory{{"api:v1", 0}},
+			container:   "app",
+			annotations: map[string]string{AnnotationLastStableImage: "api:v0"},
+			expectImage: "api:v0",
+		},
+		{
+			name:           "skips a revision whose images a rollback replaced",
+			history:        rolloutHistory{{"api:v1", 0}, {"api:v2", 2 * time.Hour}, {"api:v3", 4 * time.Hour}},
+			annotations:    map[string]string{AnnotationAttemptedImages: `{"containers":{"app":"api:v2"}}`},
+			expectRevision: 1,
+			expectImage:    "api:v1",
+		},
+		{
+			name:    "falls back to the stable-images history past known-bad images",
+			history: rolloutHistory{{"api:v1", 0}},
+			annotations: map[string]string{
+				AnnotationStableImages:        `{"containers":{"app":"api:v0.9"}}`,
+				AnnotationStableImagesHistory: `[{"containers":{"app":"api:v0.9"}},{"containers":{"app":"api:v0.8"}}]`,
+				AnnotationAttemptedImages:     `{"containers":{"app":"api:v0.9"}}`,
+			},
+			expectImage: "api:v0.8",
+		},
+		{
+			name:    "stable-images history holds only known-bad images",
+			history: rolloutHistory{{"api:v1", 0}},
+			annotations: map[string]string{
+				AnnotationStableImagesHistory: `[{"containers":{"app":"api:v0.9"}}]`,
+				AnnotationAttemptedImages:     `{"containers":{"app":"api:v0.9"}}`,
+			},
+			expectError: true,
+		},
+		{
+			name:        "last-stable-image is ambiguous without a target container",
+			history:     rolloutHistory{{"api:v1", 0}},
+			annotations: map[string]string{AnnotationLastStableImage: "api:v0"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment, objects := deploymentWithHistory(tt.history)
+			for k, v := range tt.annotations {
+				deployment.Annotations[k] = v
+			}
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+			rollback, err := ApplyRollbackImage(context.Background(), cl, deployment, tt.container, tt.params)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if rollback.Revision != tt.expectRevision {
+				t.Errorf("Expected revision %d, got %d (%s)", tt.expectRevision, rollback.Revision, rollback.Source)
+			}
+			if rollback.Images["app"] != tt.expectImage {
+				t.Errorf("Expected status image %s, got %v", tt.expectImage, rollback.Images)
+			}
+			if got := deployment.Spec.Template.Spec.Containers[0].Image; got != tt.expectImage {
+				t.Errorf("Expected app image %s, got %s", tt.expectImage, got)
+			}
+			if got := deployment.Spec.Template.Spec.Containers[1].Image; got != "envoy:1.29" {
+				t.Errorf("Expected the unchanged sidecar to keep its image, got %s", got)
+			}
+			if _, ok := rollback.Images["proxy"]; ok {
+				t.Errorf("Expected only changed containers in status, got %v", rollback.Images)
+			}
+		})
+	}
+}
+
+func TestApplyRollbackImage_ControllerRevisions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	isController := true
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: types.UID("uid-db")},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: podTemplate("db:v2"),
+		},
+	}
+
+	revision := func(number int64, image string, at time.Duration) *appsv1.ControllerRevision {
+		template := podTemplate(image)
+		data, _ := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{"template": template},
+		})
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("db-%d", number),
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "api"},
+				CreationTimestamp: metav1.NewTime(start.Add(at)),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: statefulSet.UID, Controller: &isController,
+				}},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: number,
+		}
+	}
+
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(statefulSet, revision(1, "db:v1", 0), revision(2, "db:v2", time.Hour)).
+		Build()
+
+	rollback, err := ApplyRollbackImage(context.Background(), cl, statefulSet, "", nil)
+	if err != nil {
+		t.Fatalf("ApplyRollbackImage failed: %v", err)
+	}
+	if rollback.Revision != 1 || rollback.Source != "ControllerRevision db-1" {
+		t.Errorf("Expected ControllerRevision db-1 (revision 1), got %+v", rollback)
+	}
+	if got := statefulSet.Spec.Template.Spec.Containers[0].Image; got != "db:v1" {
+		t.Errorf("Expected app image db:v1, got %s", got)
+	}
+}
+
+func TestApplyRollbackImage_ReusedReplicaSet(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	// api:v2 was rolled back to as revision 4 after being current for two
+	// minutes as revision 2; its ReplicaSet keeps the old creation time
+	deployment, objects := deploymentWithHistory(rolloutHistory{
+		{"api:v1", 0},
+		{"api:v2", time.Hour},
+		{"api:v3", time.Hour + 2*time.Minute},
+		{"api:v5", 5 * time.Hour},
+	})
+	deployment.Annotations[annotationDeploymentRevision] = "5"
+	objects[2].SetAnnotations(map[string]string{
+		annotationDeploymentRevision:        "4",
+		annotationDeploymentRevisionHistory: "2",
+	})
+	objects[4].SetAnnotations(map[string]string{annotationDeploymentRevision: "5"})
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	rollback, err := ApplyRollbackImage(context.Background(), cl, deployment, "", nil)
+	if err != nil {
+		t.Fatalf("ApplyRollbackImage failed: %v", err)
+	}
+	if rollback.Revision != 1 || rollback.Source != "ReplicaSet api-1" {
+		t.Errorf("Expected ReplicaSet api-1 (revision 1), got %+v", rollback)
+	}
+	if got := deployment.Spec.Template.Spec.Containers[0].Image; got != "api:v1" {
+		t.Errorf("Expected app image api:v1, got %s", got)
+	}
+}
+
+func TestApplyRollbackImage_ReusedControllerRevision(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	isController := true
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: types.UID("uid-db")},
+		Spec: appsv1.StatefulSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+			Template: podTemplate("db:v3"),
+		},
+	}
+
+	revision := func(name string, number int64, image string, at, updated time.Duration) *appsv1.ControllerRevision {
+		template := podTemplate(image)
+		data, _ := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{"template": template},
+		})
+		updatedAt := metav1.NewTime(start.Add(updated))
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "api"},
+				CreationTimestamp: metav1.NewTime(start.Add(at)),
+				ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kube-controller-manager", Time: &updatedAt}},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "StatefulSet", Name: "db", UID: statefulSet.UID, Controller: &isController,
+				}},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: number,
+		}
+	}
+
+	// db:v1 was current for two minutes, then rolled back to as revision 3
+	// two hours later; its ControllerRevision keeps the old creation time
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			statefulSet,
+			revision("db-a", 3, "db:v1", 0, 2*time.Hour),
+			revision("db-b", 2, "db:v2", 2*time.Minute, 2*time.Minute),
+			revision("db-c", 4, "db:v3", 2*time.Hour+time.Minute, 2*time.Hour+time.Minute),
+		).
+		Build()
+
+	_, err := ApplyRollbackImage(context.Background(), cl, statefulSet, "", nil)
+	if !errors.Is(err, ErrNoStableRevision) {
+		t.Fatalf("Expected ErrNoStableRevision, got %v", err)
+	}
+	if got := statefulSet.Spec.Template.Spec.Containers[0].Image; got != "db:v3" {
+		t.Errorf("Expected app image to stay db:v3, got %s", got)
+	}
+}
+
+func TestApplyRollbackImage_PerContainer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = appsv1.AddToScheme(scheme)
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	isController := true
+	template := func(app, proxy, migrate string) corev1.PodTemplateSpec {
+		return corev1.PodTemplateSpec{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "api"}},
+			Spec: corev1.PodSpec{
+				InitContainers: []corev1.Container{{Name: "migrate", Image: migrate}},
+				Containers: []corev1.Container{
+					{Name: "app", Image: app},
+					{Name: "proxy", Image: proxy},
+				},
+			},
+		}
+	}
+	replicaSet := func(revision int, spec corev1.PodTemplateSpec, at time.Duration) *appsv1.ReplicaSet {
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              fmt.Sprintf("api-%d", revision),
+				Namespace:         "default",
+				Labels:            map[string]string{"app": "api"},
+				Annotations:       map[string]string{annotationDeploymentRevision: strconv.Itoa(revision)},
+				CreationTimestamp: metav1.NewTime(start.Add(at)),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: types.UID("uid-api"), Controller: &isController,
+				}},
+			},
+			Spec: appsv1.ReplicaSetSpec{Template: spec},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		previous       corev1.PodTemplateSpec
+		current        corev1.PodTemplateSpec
+		container      string
+		expectNoStable bool
+		expectError    bool
+		expectApp      string
+		expectProxy    string
+		expectMigrate  string
+		expectImages   map[string]string
+	}{
+		{
+			name:          "rolls back each container to its own image",
+			previous:      template("api:v1", "envoy:1.28", "migrate:v1"),
+			current:       template("api:v2", "envoy:1.29", "migrate:v1"),
+			expectApp:     "api:v1",
+			expectProxy:   "envoy:1.28",
+			expectMigrate: "migrate:v1",
+			expectImages:  map[string]string{"app": "api:v1", "proxy": "envoy:1.28"},
+		},
+		{
+			name:          "target container leaves the sidecar alone",
+			previous:      template("api:v1", "envoy:1.28", "migrate:v1"),
+			current:       template("api:v2", "envoy:1.29", "migrate:v1"),
+			container:     "app",
+			expectApp:     "api:v1",
+			expectProxy:   "envoy:1.29",
+			expectMigrate: "migrate:v1",
+			expectImages:  map[string]string{"app": "api:v1"},
+		},
+		{
+			name:          "init container changed in the same rollout",
+			previous:      template("api:v1", "envoy:1.29", "migrate:v1"),
+			current:       template("api:v2", "envoy:1.29", "migrate:v2"),
+			container:     "app",
+			expectApp:     "api:v1",
+			expectProxy:   "envoy:1.29",
+			expectMigrate: "migrate:v1",
+			expectImages:  map[string]string{"app": "api:v1"},
+		},
+		{
+			name:           "only another container changed",
+			previous:       template("api:v2", "envoy:1.28", "migrate:v1"),
+			current:        template("api:v2", "envoy:1.29", "migrate:v1"),
+			container:      "app",
+			expectNoStable: true,
+		},
+		{
+			name:        "unknown target container",
+			previous:    template("api:v1", "envoy:1.29", "migrate:v1"),
+			current:     template("api:v2", "envoy:1.29", "migrate:v1"),
+			container:   "worker",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "default", UID: types.UID("uid-api")},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "api"}},
+					Template: *tt.current.DeepCopy(),
+				},
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(deployment, replicaSet(1, tt.previous, 0), replicaSet(2, tt.current, time.Hour)).
+				Build()
+
+			rollback, err := ApplyRollbackImage(context.Background(), cl, deployment, tt.container, nil)
+			if tt.expectNoStable {
+				if !errors.Is(err, ErrNoStableRevision) {
+					t.Errorf("Expected ErrNoStableRevision, got %v", err)
+				}
+				return
+			}
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			spec := deployment.Spec.Template.Spec
+			if spec.Containers[0].Image != tt.expectApp || spec.Containers[1].Image != tt.expectProxy || spec.InitContainers[0].Image != tt.expectMigrate {
+				t.Errorf("Expected app=%s proxy=%s migrate=%s, got app=%s proxy=%s migrate=%s",
+					tt.expectApp, tt.expectProxy, tt.expectMigrate,
+					spec.Containers[0].Image, spec.Containers[1].Image, spec.InitContainers[0].Image)
+			}
+			if len(rollback.Images) != len(tt.expectImages) {
+				t.Errorf("Expected status images %v, got %v", tt.expectImages, rollback.Images)
+			}
+			for name, image := range tt.expectImages {
+				if rollback.Images[name] != image {
+					t.Errorf("Expected status image %s=%s, got %v", name, image, rollback.Images)
+				}
+			}
+			if tt.previous.Spec.InitContainers[0].Image != tt.current.Spec.InitContainers[0].Image && rollback.InitImages["migrate"] != tt.expectMigrate {
+				t.Errorf("Expected init image in status, got %v", rollback.InitImages)
+			}
+
+			var attempted ImageSet
+			if err := json.Unmarshal([]byte(deployment.Annotations[AnnotationAttemptedImages]), &attempted); err != nil {
+				t.Fatalf("Expected %s annotation: %v", AnnotationAttemptedImages, err)
+			}
+			for name := range tt.expectImages {
+				if attempted.Containers[name] == "" || attempted.Containers[name] == tt.expectImages[name] {
+					t.Errorf("Expected the replaced image of %s in %s, got %v", name, AnnotationAttemptedImages, attempted)
+				}
+			}
+		})
+	}
+}
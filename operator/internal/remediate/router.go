@@ -17,11 +17,33 @@ limitations under the License.
 package remediate
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
 )
 
+// Alert sources recorded in spec.alert.source
+const (
+	// SourceAlertmanager is the source of alerts received on the Alertmanager webhook
+	SourceAlertmanager = "alertmanager"
+
+	// SourceGrafana is the source of Grafana Alerting notifications
+	SourceGrafana = "grafana"
+
+	// SourceGeneric is the source of alerts posted in the generic JSON schema
+	SourceGeneric = "generic"
+
+	// SourceDetector is the source of alerts raised by the in-cluster detectors
+	SourceDetector = "detector"
+)
+
 // Alert represents a simplified Alertmanager alert
 type Alert struct {
 	Labels      map[string]string `json:"labels"`
@@ -29,17 +51,33 @@ type Alert struct {
 	Status      string            `json:"status"`
 	StartsAt    string            `json:"startsAt"`
 	Fingerprint string            `json:"fingerprint"`
+	// Source is where the alert came from; defaults to "alertmanager"
+	Source string `json:"source,omitempty"`
 }
 
-// RouterConfig holds the configuration for alert routing
+// RouterConfig holds the configuration for alert routing. Rules are
+// evaluated in order; like Alertmanager routes, evaluation stops at the first
+// matching rule unless that rule sets Continue.
 type RouterConfig struct {
-	Routes map[string]RouteConfig
+	Rules []RouteRule
 }
 
-// RouteConfig defines how to handle a specific alert type
-type RouteConfig struct {
+// RouteRule maps alerts matching all of its label matchers to an action
+type RouteRule struct {
+	// Name identifies the rule and is recorded on the Remediation
+	Name string
+	// Matchers must all match the alert labels; an empty list matches every alert
+	Matchers   []*Matcher
 	ActionType ActionType
 	Params     map[string]string
+	// Continue keeps evaluating later rules after this one matched
+	Continue bool
+}
+
+// RouteResult is the remediation produced by one matching rule
+type RouteResult struct {
+	Rule string
+	Spec *k8shealerv1alpha1.RemediationSpec
 }
 
 // ActionType represents the remediation action type
@@ -47,55 +85,229 @@ type ActionType string
 
 const (
 	ActionTypeIncreaseMemory ActionType = "IncreaseMemory"
+	ActionTypeIncreaseCPU    ActionType = "IncreaseCPU"
 	ActionTypeScaleUp        ActionType = "ScaleUp"
+	ActionTypeRestartPods    ActionType = "RestartPods"
 	ActionTypeRollbackImage  ActionType = "RollbackImage"
+	ActionTypeCustomScript   ActionType = "CustomScript"
 )
 
 // DefaultRouterConfig returns the default alert routing configuration
 func DefaultRouterConfig() RouterConfig {
 	return RouterConfig{
-		Routes: map[string]RouteConfig{
-			"KubePodOOMKilled": {
+		Rules: []RouteRule{
+			{
+				Name:       "KubePodOOMKilled",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="KubePodOOMKilled"`)},
 				ActionType: ActionTypeIncreaseMemory,
 				Params: map[string]string{
 					"memoryIncreasePercent": "25",
 					"maxMemory":             "2Gi",
 				},
 			},
-			"ContainerOOMKilled": {
+			{
+				Name:       "ContainerOOMKilled",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="ContainerOOMKilled"`)},
 				ActionType: ActionTypeIncreaseMemory,
 				Params: map[string]string{
 					"memoryIncreasePercent": "25",
 					"maxMemory":             "2Gi",
 				},
 			},
-			"KubeHpaMaxedOut": {
+			{
+				Name:       "CPUThrottlingHigh",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="CPUThrottlingHigh"`)},
+				ActionType: ActionTypeIncreaseCPU,
+				Params: map[string]string{
+					"cpuIncreasePercent": "50",
+					"cpuRoundTo":         "100m",
+					"maxCPU":             "4",
+				},
+			},
+			{
+				Name:       "KubeHpaMaxedOut",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="KubeHpaMaxedOut"`)},
 				ActionType: ActionTypeScaleUp,
 				Params: map[string]string{
 					"scaleUpPercent": "50",
 					"maxReplicas":    "10",
 				},
 			},
-			"KubePodCrashLooping": {
+			{
+				Name:       "KubePodCrashLooping",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="KubePodCrashLooping"`)},
 				ActionType: ActionTypeRollbackImage,
 				Params: map[string]string{
-					"rollbackMaxRevisions": "5",
+					"rollbackMaxRevisions":    "5",
+					"rollbackStabilityPeriod": "10m",
+				},
+			},
+			{
+				Name:       "KubePodImagePullBackOff",
+				Matchers:   []*Matcher{MustParseMatcher(`alertname="KubePodImagePullBackOff"`)},
+				ActionType: ActionTypeRollbackImage,
+				Params: map[string]string{
+					"rollbackMaxRevisions":    "5",
+					"rollbackStabilityPeriod": "10m",
 				},
 			},
 		},
 	}
 }
 
-// RouteAlert determines the remediation action for an alert
-func RouteAlert(alert Alert, config RouterConfig) (*k8shealerv1alpha1.RemediationSpec, error) {
+// Validate checks that rule names are unique and usable as label values,
+// that every rule uses a known action type, that well-known numeric and
+// quantity params parse and that templated params are valid templates.
+func (c RouterConfig) Validate() error {
+	seen := make(map[string]bool, len(c.Rules))
+
+	for i, rule := range c.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rule %d has an empty name", i)
+		}
+		if errs := validation.IsValidLabelValue(rule.Name); len(errs) > 0 {
+			return fmt.Errorf("rule %q: invalid name: %s", rule.Name, strings.Join(errs, "; "))
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("rule %q: duplicate name", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the action type and params of a single rule
+func (r RouteRule) Validate() error {
+	switch r.ActionType {
+	case ActionTypeIncreaseMemory, ActionTypeIncreaseCPU, ActionTypeScaleUp, ActionTypeRestartPods, ActionTypeRollbackImage:
+	case ActionTypeCustomScript:
+		_, hasTemplate := r.Params[ParamJobTemplate]
+		_, hasConfigMap := r.Params[ParamJobConfigMap]
+		if hasTemplate == hasConfigMap {
+			return fmt.Errorf("%s requires exactly one of the %s and %s params", r.ActionType, ParamJobTemplate, ParamJobConfigMap)
+		}
+	case "":
+		return fmt.Errorf("action is required")
+	default:
+		return fmt.Errorf("unknown action %q", r.ActionType)
+	}
+
+	for _, m := range r.Matchers {
+		if m == nil {
+			return fmt.Errorf("nil matcher")
+		}
+	}
+
+	static := make(map[string]string, len(r.Params))
+	for key, value := range r.Params {
+		if !isParamTemplate(value) {
+			static[key] = value
+			continue
+		}
+		// Templated values are checked after rendering, when the alert is known
+		if _, err := parseParamTemplate(key, value); err != nil {
+			return err
+		}
+	}
+
+	return ValidateParams(static)
+}
+
+// ValidateParams checks the values of known action params
+func ValidateParams(params map[string]string) error {
+	for key, value := range params {
+		switch key {
+		case "memoryIncreasePercent", "cpuIncreasePercent", "scaleUpPercent", "maxReplicas", "rollbackMaxRevisions":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("param %s: %q is not an integer", key, value)
+			}
+			if n <= 0 {
+				return fmt.Errorf("param %s: must be positive, got %d", key, n)
+			}
+		case "maxMemory":
+			if _, err := resource.ParseQuantity(value); err != nil {
+				return fmt.Errorf("param %s: %q is not a valid quantity", key, value)
+			}
+		case "maxCPU", "cpuRoundTo":
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return fmt.Errorf("param %s: %q is not a valid quantity", key, value)
+			}
+			if q.Sign() <= 0 {
+				return fmt.Errorf("param %s: must be positive, got %s", key, value)
+			}
+		case "restartScope":
+			if value != RestartScopeWorkload && value != RestartScopePod {
+				return fmt.Errorf("param %s: must be %s or %s, got %q", key, RestartScopeWorkload, RestartScopePod, value)
+			}
+		case "restartCooldown", "rollbackStabilityPeriod":
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("param %s: %q is not a duration", key, value)
+			}
+			if d < 0 {
+				return fmt.Errorf("param %s: must not be negative, got %s", key, value)
+			}
+		case ParamJobTemplate, ParamJobConfigMap:
+			if errs := validation.IsDNS1123Subdomain(value); len(errs) > 0 {
+				return fmt.Errorf("param %s: %q is not a valid name: %s", key, value, strings.Join(errs, "; "))
+			}
+		case "removeCPULimit":
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("param %s: %q is not a boolean", key, value)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether all of the rule's matchers match the labels
+func (r RouteRule) Matches(labels map[string]string) bool {
+	for _, m := range r.Matchers {
+		if !m.Matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrNoRoute is returned by RouteAlert when no rule matches the alert
+var ErrNoRoute = errors.New("no route matched alert")
+
+// TargetResolver replaces the target derived from the alert labels, e.g. a
+// Pod with the workload that owns it
+type TargetResolver func(target *k8shealerv1alpha1.TargetResource)
+
+// RouteAlert determines the remediation actions for an alert. It returns one
+// result per matching rule: normally a single result, more when a matching
+// rule has Continue set. If resolve is not nil it is applied to the target
+// before params are rendered, so templates see the final target.
+func RouteAlert(alert Alert, config RouterConfig, resolve TargetResolver) ([]RouteResult, error) {
 	alertname := alert.Labels["alertname"]
 	if alertname == "" {
 		return nil, fmt.Errorf("alert has no alertname label")
 	}
 
-	route, ok := config.Routes[alertname]
-	if !ok {
-		return nil, fmt.Errorf("no route configured for alert: %s", alertname)
+	var matched []RouteRule
+	for _, rule := range config.Rules {
+		if !rule.Matches(alert.Labels) {
+			continue
+		}
+		matched = append(matched, rule)
+		if !rule.Continue {
+			break
+		}
+	}
+
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNoRoute, alertname)
 	}
 
 	// Extract target information from alert labels
@@ -103,28 +315,55 @@ func RouteAlert(alert Alert, config RouterConfig) (*k8shealerv1alpha1.Remediatio
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract target from alert: %w", err)
 	}
+	if resolve != nil {
+		resolve(target)
+	}
 
-	spec := &k8shealerv1alpha1.RemediationSpec{
-		Alert: k8shealerv1alpha1.AlertInfo{
-			Name:        alertname,
-			Fingerprint: alert.Fingerprint,
-			Source:      "alertmanager",
-			Severity:    alert.Labels["severity"],
-		},
-		Target: *target,
-		Action: k8shealerv1alpha1.Action{
-			Type:   k8shealerv1alpha1.ActionType(route.ActionType),
-			Params: route.Params,
-		},
-		Strategy: k8shealerv1alpha1.Strategy{
-			Mode:            k8shealerv1alpha1.StrategyModeGitOps,
-			RequireApproval: true,
-			Environment:     alert.Labels["environment"],
-			TTL:             "24h",
-		},
+	source := alert.Source
+	if source == "" {
+		source = SourceAlertmanager
+	}
+
+	templateData := ParamTemplateData{
+		Labels:      alert.Labels,
+		Annotations: alert.Annotations,
+		Target:      *target,
+		Fingerprint: alert.Fingerprint,
+		Source:      source,
+	}
+
+	results := make([]RouteResult, 0, len(matched))
+	for _, rule := range matched {
+		params, err := renderParams(rule.Params, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+
+		spec := &k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:        alertname,
+				Fingerprint: alert.Fingerprint,
+				Source:      source,
+				Severity:    alert.Labels["severity"],
+				Route:       rule.Name,
+			},
+			Target: *target,
+			Action: k8shealerv1alpha1.Action{
+				Type:   k8shealerv1alpha1.ActionType(rule.ActionType),
+				Params: params,
+			},
+			Strategy: k8shealerv1alpha1.Strategy{
+				Mode:            k8shealerv1alpha1.StrategyModeGitOps,
+				RequireApproval: true,
+				Environment:     alert.Labels["environment"],
+				TTL:             "24h",
+			},
+		}
+
+		results = append(results, RouteResult{Rule: rule.Name, Spec: spec})
 	}
 
-	return spec, nil
+	return results, nil
 }
 
 // extractTargetFromAlert extracts target resource information from alert labels
@@ -136,9 +375,9 @@ func extractTargetFromAlert(alert Alert) (*k8shealerv1alpha1.TargetResource, err
 
 	// Try to determine the resource kind and name from labels
 	// Common patterns:
-	// - pod: "pod_name" -> extract deployment/statefulset from pod owner
 	// - deployment: "deployment"
 	// - statefulset: "statefulset"
+	// - pod: "pod_name" -> resolved to the owning workload by the controller
 
 	var kind, name, container string
 
@@ -150,12 +389,9 @@ func extractTargetFromAlert(alert Alert) (*k8shealerv1alpha1.TargetResource, err
 		kind = "StatefulSet"
 		name = statefulsetName
 	} else if podName := alert.Labels["pod"]; podName != "" {
-		// Try to infer from pod name (e.g., "api-service-5f7b8c9d-xyz" -> "api-service")
-		// This is a simplified approach - in production, you'd query the pod and get owner ref
-		kind = "Deployment"
-		// Extract base name (remove replica set hash and pod hash)
-		// This is approximate - better to query the actual pod
-		name = extractDeploymentNameFromPod(podName)
+		// The controller walks the pod's ownerReferences to find the real target
+		kind = "Pod"
+		name = podName
 	} else {
 		return nil, fmt.Errorf("cannot determine target resource from alert labels")
 	}
@@ -170,13 +406,3 @@ func extractTargetFromAlert(alert Alert) (*k8shealerv1alpha1.TargetResource, err
 		Container: container,
 	}, nil
 }
-
-// extractDeploymentNameFromPod tries to extract deployment name from pod name
-// Pod naming pattern: <deployment>-<replicaset-hash>-<pod-hash>
-func extractDeploymentNameFromPod(podName string) string {
-	// Simple heuristic: remove last two segments (replicaset hash and pod hash)
-	// In production, you should query the pod and get owner references
-
-	// For now, just return the pod name - the controller will need to handle this
-	return podName
-}
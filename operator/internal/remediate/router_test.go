@@ -34,6 +34,22 @@ func TestRouteAlert(t *testing.T) {
 			expectError:  false,
 			expectAction: k8shealerv1alpha1.ActionTypeIncreaseMemory,
 		},
+		{
+			name: "CPUThrottlingHigh alert",
+			alert: Alert{
+				Labels: map[string]string{
+					"alertname": "CPUThrottlingHigh",
+					"namespace": "prod",
+					"pod":       "api-service-7d9f8b6c5-x2k4p",
+					"container": "api",
+					"severity":  "info",
+				},
+				Status:      "firing",
+				Fingerprint: "cpu789",
+			},
+			expectError:  false,
+			expectAction: k8shealerv1alpha1.ActionTypeIncreaseCPU,
+		},
 		{
 			name: "KubeHpaMaxedOut alert",
 			alert: Alert{
@@ -76,7 +92,7 @@ func TestRouteAlert(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			spec, err := RouteAlert(tt.alert, config)
+			results, err := RouteAlert(tt.alert, config, nil)
 
 			if tt.expectError {
 				if err == nil {
@@ -90,6 +106,19 @@ func TestRouteAlert(t *testing.T) {
 				return
 			}
 
+			if len(results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(results))
+			}
+			spec := results[0].Spec
+
+			if results[0].Rule != tt.alert.Labels["alertname"] {
+				t.Errorf("expected rule %s, got %s", tt.alert.Labels["alertname"], results[0].Rule)
+			}
+
+			if spec.Alert.Route != results[0].Rule {
+				t.Errorf("expected spec.alert.route %s, got %s", results[0].Rule, spec.Alert.Route)
+			}
+
 			if spec.Action.Type != tt.expectAction {
 				t.Errorf("expected action %s, got %s", tt.expectAction, spec.Action.Type)
 			}
@@ -105,6 +134,140 @@ func TestRouteAlert(t *testing.T) {
 	}
 }
 
+func TestRouteAlert_LabelMatchers(t *testing.T) {
+	config := RouterConfig{
+		Rules: []RouteRule{
+			{
+				Name: "oom-batch",
+				Matchers: []*Matcher{
+					MustParseMatcher(`alertname="KubePodOOMKilled"`),
+					MustParseMatcher(`namespace=~"batch-.*"`),
+				},
+				ActionType: ActionTypeIncreaseMemory,
+				Params:     map[string]string{"maxMemory": "8Gi"},
+			},
+			{
+				Name: "oom-payments-scale",
+				Matchers: []*Matcher{
+					MustParseMatcher(`alertname="KubePodOOMKilled"`),
+					MustParseMatcher(`namespace="payments"`),
+				},
+				ActionType: ActionTypeScaleUp,
+				Continue:   true,
+			},
+			{
+				Name: "oom-default",
+				Matchers: []*Matcher{
+					MustParseMatcher(`alertname="KubePodOOMKilled"`),
+					MustParseMatcher(`severity!="info"`),
+				},
+				ActionType: ActionTypeIncreaseMemory,
+				Params:     map[string]string{"maxMemory": "2Gi"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		labels      map[string]string
+		expectRules []string
+		expectError bool
+	}{
+		{
+			name:        "regex match stops at first rule",
+			labels:      map[string]string{"namespace": "batch-nightly"},
+			expectRules: []string{"oom-batch"},
+		},
+		{
+			name:        "regex is anchored",
+			labels:      map[string]string{"namespace": "prod-batch-x"},
+			expectRules: []string{"oom-default"},
+		},
+		{
+			name:        "continue evaluates later rules",
+			labels:      map[string]string{"namespace": "payments"},
+			expectRules: []string{"oom-payments-scale", "oom-default"},
+		},
+		{
+			name:        "not-equals excludes",
+			labels:      map[string]string{"namespace": "prod", "severity": "info"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels := map[string]string{
+				"alertname":  "KubePodOOMKilled",
+				"deployment": "worker",
+			}
+			for k, v := range tt.labels {
+				labels[k] = v
+			}
+
+			results, err := RouteAlert(Alert{Labels: labels, Fingerprint: "fp"}, config, nil)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(results) != len(tt.expectRules) {
+				t.Fatalf("expected rules %v, got %d results", tt.expectRules, len(results))
+			}
+			for i, rule := range tt.expectRules {
+				if results[i].Rule != rule {
+					t.Errorf("result %d: expected rule %s, got %s", i, rule, results[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestParseMatcher(t *testing.T) {
+	tests := []struct {
+		input       string
+		expectError bool
+		labels      map[string]string
+		expectMatch bool
+	}{
+		{input: `alertname="KubePodOOMKilled"`, labels: map[string]string{"alertname": "KubePodOOMKilled"}, expectMatch: true},
+		{input: `alertname=KubePodOOMKilled`, labels: map[string]string{"alertname": "KubePodOOMKilled"}, expectMatch: true},
+		{input: `namespace!="kube-system"`, labels: map[string]string{"namespace": "kube-system"}, expectMatch: false},
+		{input: `namespace!=kube-system`, labels: map[string]string{}, expectMatch: true},
+		{input: `namespace=~"batch-.*"`, labels: map[string]string{"namespace": "batch-a"}, expectMatch: true},
+		{input: `namespace!~"batch-.*"`, labels: map[string]string{"namespace": "batch-a"}, expectMatch: false},
+		{input: `team=""`, labels: map[string]string{}, expectMatch: true},
+		{input: `namespace=~"("`, expectError: true},
+		{input: `="value"`, expectError: true},
+		{input: `namespace`, expectError: true},
+		{input: `namespace="unterminated`, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			m, err := ParseMatcher(tt.input)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := m.Matches(tt.labels); got != tt.expectMatch {
+				t.Errorf("expected match %v, got %v", tt.expectMatch, got)
+			}
+		})
+	}
+}
+
 func TestExtractTargetFromAlert(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -150,7 +313,8 @@ func TestExtractTargetFromAlert(t *testing.T) {
 				},
 			},
 			expectError: false,
-			expectKind:  "Deployment",
+			expectKind:  "Pod",
+			expectName:  "api-service-abc123-xyz",
 			expectNs:    "prod",
 		},
 		{
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const (
+	// AnnotationEnabled turns remediation on ("true") or off ("false") for a
+	// namespace or workload
+	AnnotationEnabled = "heal8s.io/enabled"
+
+	// AnnotationActions restricts remediation to a comma-separated list of action types
+	AnnotationActions = "heal8s.io/actions"
+
+	// AnnotationMode overrides the strategy mode (Direct or GitOps)
+	AnnotationMode = "heal8s.io/mode"
+)
+
+// Scope is the operator-level choice of where remediation may happen
+type Scope struct {
+	// IncludeNamespaces limits remediation to these namespaces (glob
+	// patterns). Empty means all namespaces.
+	IncludeNamespaces []string
+
+	// ExcludeNamespaces are never remediated, even if included
+	ExcludeNamespaces []string
+
+	// RequireOptIn only remediates namespaces or workloads annotated with
+	// heal8s.io/enabled=true
+	RequireOptIn bool
+}
+
+// Validate checks the namespace patterns
+func (s Scope) Validate() error {
+	for _, pattern := range append(append([]string{}, s.IncludeNamespaces...), s.ExcludeNamespaces...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid namespace pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// AllowsNamespace reports whether the include/exclude lists permit namespace
+func (s Scope) AllowsNamespace(namespace string) bool {
+	if len(s.IncludeNamespaces) > 0 && !matchesAny(s.IncludeNamespaces, namespace) {
+		return false
+	}
+	return !matchesAny(s.ExcludeNamespaces, namespace)
+}
+
+func matchesAny(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, namespace); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// OptInDecision is the result of evaluating the heal8s.io annotations
+type OptInDecision struct {
+	// Allowed is false if the namespace or workload opted out of the action
+	Allowed bool
+
+	// Reason explains why the action is not allowed
+	Reason string
+
+	// Mode is the strategy mode requested by annotation, if any
+	Mode k8shealerv1alpha1.StrategyMode
+}
+
+// EvaluateOptIn applies the namespace and workload annotations to action.
+// Workload annotations take precedence over namespace annotations key by key.
+func (s Scope) EvaluateOptIn(namespaceAnnotations, workloadAnnotations map[string]string, action k8shealerv1alpha1.ActionType) (OptInDecision, error) {
+	lookup := func(key string) (string, bool) {
+		if v, ok := workloadAnnotations[key]; ok {
+			return v, true
+		}
+		v, ok := namespaceAnnotations[key]
+		return v, ok
+	}
+
+	if value, ok := lookup(AnnotationEnabled); ok {
+		enabled, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return OptInDecision{}, fmt.Errorf("invalid %s annotation %q", AnnotationEnabled, value)
+		}
+		if !enabled {
+			return OptInDecision{Reason: fmt.Sprintf("%s is false", AnnotationEnabled)}, nil
+		}
+	} else if s.RequireOptIn {
+		return OptInDecision{Reason: fmt.Sprintf("opt-in required and %s is not set", AnnotationEnabled)}, nil
+	}
+
+	if value, ok := lookup(AnnotationActions); ok {
+		allowed := false
+		for _, a := range strings.Split(value, ",") {
+			if strings.TrimSpace(a) == string(action) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return OptInDecision{Reason: fmt.Sprintf("action %s is not in %s=%q", action, AnnotationActions, value)}, nil
+		}
+	}
+
+	decision := OptInDecision{Allowed: true}
+	if value, ok := lookup(AnnotationMode); ok {
+		mode := k8shealerv1alpha1.StrategyMode(strings.TrimSpace(value))
+		switch mode {
+		case k8shealerv1alpha1.StrategyModeDirect, k8shealerv1alpha1.StrategyModeGitOps:
+			decision.Mode = mode
+		default:
+			return OptInDecision{}, fmt.Errorf("invalid %s annotation %q: must be Direct or GitOps", AnnotationMode, value)
+		}
+	}
+
+	return decision, nil
+}
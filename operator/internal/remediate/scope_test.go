@@ -0,0 +1,117 @@
+package remediate
+
+import (
+	"testing"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestScope_AllowsNamespace(t *testing.T) {
+	tests := []struct {
+		name      string
+		scope     Scope
+		namespace string
+		expected  bool
+	}{
+		{name: "empty scope", namespace: "prod", expected: true},
+		{name: "included", scope: Scope{IncludeNamespaces: []string{"prod", "team-*"}}, namespace: "team-a", expected: true},
+		{name: "not included", scope: Scope{IncludeNamespaces: []string{"prod"}}, namespace: "dev", expected: false},
+		{name: "excluded", scope: Scope{ExcludeNamespaces: []string{"kube-*"}}, namespace: "kube-system", expected: false},
+		{name: "exclude wins", scope: Scope{IncludeNamespaces: []string{"*"}, ExcludeNamespaces: []string{"prod"}}, namespace: "prod", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.scope.AllowsNamespace(tt.namespace); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestScope_EvaluateOptIn(t *testing.T) {
+	tests := []struct {
+		name        string
+		scope       Scope
+		namespace   map[string]string
+		workload    map[string]string
+		expectAllow bool
+		expectMode  k8shealerv1alpha1.StrategyMode
+		expectError bool
+	}{
+		{
+			name:        "no annotations",
+			expectAllow: true,
+		},
+		{
+			name:      "opt-in required",
+			scope:     Scope{RequireOptIn: true},
+			namespace: map[string]string{},
+		},
+		{
+			name:        "namespace opted in",
+			scope:       Scope{RequireOptIn: true},
+			namespace:   map[string]string{AnnotationEnabled: "true"},
+			expectAllow: true,
+		},
+		{
+			name:      "namespace opted out",
+			namespace: map[string]string{AnnotationEnabled: "false"},
+		},
+		{
+			name:        "workload overrides namespace",
+			namespace:   map[string]string{AnnotationEnabled: "false"},
+			workload:    map[string]string{AnnotationEnabled: "true"},
+			expectAllow: true,
+		},
+		{
+			name:        "action allowed",
+			workload:    map[string]string{AnnotationActions: "ScaleUp, IncreaseMemory"},
+			expectAllow: true,
+		},
+		{
+			name:     "action not allowed",
+			workload: map[string]string{AnnotationActions: "ScaleUp"},
+		},
+		{
+			name:        "mode override",
+			namespace:   map[string]string{AnnotationMode: "Direct"},
+			expectAllow: true,
+			expectMode:  k8shealerv1alpha1.StrategyModeDirect,
+		},
+		{
+			name:        "invalid mode",
+			workload:    map[string]string{AnnotationMode: "Yolo"},
+			expectError: true,
+		},
+		{
+			name:        "invalid enabled",
+			workload:    map[string]string{AnnotationEnabled: "maybe"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := tt.scope.EvaluateOptIn(tt.namespace, tt.workload, k8shealerv1alpha1.ActionTypeIncreaseMemory)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Allowed != tt.expectAllow {
+				t.Errorf("expected allowed=%v, got %v (%s)", tt.expectAllow, decision.Allowed, decision.Reason)
+			}
+			if !decision.Allowed && decision.Reason == "" {
+				t.Error("expected a reason")
+			}
+			if decision.Mode != tt.expectMode {
+				t.Errorf("expected mode %q, got %q", tt.expectMode, decision.Mode)
+			}
+		})
+	}
+}
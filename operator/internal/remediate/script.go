@@ -0,0 +1,262 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const (
+	// ParamJobTemplate names the RemediationJobTemplate a CustomScript runs
+	ParamJobTemplate = "jobTemplate"
+
+	// ParamJobConfigMap names a ConfigMap holding the Job a CustomScript runs
+	// under JobConfigMapKey
+	ParamJobConfigMap = "jobConfigMap"
+
+	// JobConfigMapKey is the ConfigMap key holding a batch/v1 Job manifest
+	JobConfigMapKey = "job.yaml"
+
+	// LabelRemediation marks Jobs created for a Remediation
+	LabelRemediation = "k8s-healer.io/remediation"
+
+	// EnvPrefix prefixes the environment variables injected into script Jobs
+	EnvPrefix = "HEAL8S_"
+)
+
+// ScriptJob is the Job a CustomScript remediation runs, before alert details
+// are injected
+type ScriptJob struct {
+	// Source describes where the Job came from, for messages
+	Source string
+
+	Labels      map[string]string
+	Annotations map[string]string
+	Spec        batchv1.JobSpec
+}
+
+// LoadScriptJob reads the Job referenced by a CustomScript action's params
+// from namespace. Exactly one of jobTemplate and jobConfigMap must be set.
+func LoadScriptJob(ctx context.Context, cl client.Reader, namespace string, params map[string]string) (*ScriptJob, error) {
+	templateName, configMapName := params[ParamJobTemplate], params[ParamJobConfigMap]
+	switch {
+	case templateName != "" && configMapName != "":
+		return nil, fmt.Errorf("only one of %s and %s may be set", ParamJobTemplate, ParamJobConfigMap)
+
+	case templateName != "":
+		template := &k8shealerv1alpha1.RemediationJobTemplate{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: templateName}, template); err != nil {
+			return nil, fmt.Errorf("failed to get RemediationJobTemplate %s/%s: %w", namespace, templateName, err)
+		}
+		spec := *template.Spec.JobTemplate.Spec.DeepCopy()
+		spec.Template.Spec.ServiceAccountName = template.Spec.ServiceAccountName
+		return &ScriptJob{
+			Source:      "RemediationJobTemplate " + templateName,
+			Labels:      template.Spec.JobTemplate.Labels,
+			Annotations: template.Spec.JobTemplate.Annotations,
+			Spec:        spec,
+		}, nil
+
+	case configMapName != "":
+		configMap := &corev1.ConfigMap{}
+		if err := cl.Get(ctx, client.ObjectKey{Namespace: namespace, Name: configMapName}, configMap); err != nil {
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, configMapName, err)
+		}
+		manifest, ok := configMap.Data[JobConfigMapKey]
+		if !ok {
+			return nil, fmt.Errorf("ConfigMap %s/%s has no %s key", namespace, configMapName, JobConfigMapKey)
+		}
+		job := &batchv1.Job{}
+		if err := utilyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096).Decode(job); err != nil {
+			return nil, fmt.Errorf("failed to decode %s in ConfigMap %s/%s: %w", JobConfigMapKey, namespace, configMapName, err)
+		}
+		if job.Kind != "" && job.Kind != "Job" {
+			return nil, fmt.Errorf("%s in ConfigMap %s/%s is a %s, not a Job", JobConfigMapKey, namespace, configMapName, job.Kind)
+		}
+		return &ScriptJob{
+			Source:      "ConfigMap " + configMapName,
+			Labels:      job.Labels,
+			Annotations: job.Annotations,
+			Spec:        job.Spec,
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("CustomScript requires the %s or %s param", ParamJobTemplate, ParamJobConfigMap)
+	}
+}
+
+// BuildScriptJob returns the Job to create for remediation from script. The
+// alert and target are passed to every container as HEAL8S_* environment
+// variables. The pods must run as a dedicated ServiceAccount.
+func BuildScriptJob(remediation *k8shealerv1alpha1.Remediation, script *ScriptJob) (*batchv1.Job, error) {
+	spec := *script.Spec.DeepCopy()
+	podSpec := &spec.Template.Spec
+
+	if podSpec.ServiceAccountName == "" || podSpec.ServiceAccountName == "default" {
+		return nil, fmt.Errorf("%s must set a dedicated serviceAccountName", script.Source)
+	}
+	if len(podSpec.Containers) == 0 {
+		return nil, fmt.Errorf("%s has no containers", script.Source)
+	}
+	if podSpec.RestartPolicy == "" {
+		podSpec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	env, err := ScriptEnv(remediation)
+	if err != nil {
+		return nil, err
+	}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Env = mergeEnv(podSpec.InitContainers[i].Env, env)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = mergeEnv(podSpec.Containers[i].Env, env)
+	}
+
+	labels := map[string]string{}
+	for k, v := range script.Labels {
+		labels[k] = v
+	}
+	labels[LabelRemediation] = remediation.Name
+	if spec.Template.Labels == nil {
+		spec.Template.Labels = map[string]string{}
+	}
+	spec.Template.Labels[LabelRemediation] = remediation.Name
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ScriptJobName(remediation.Name),
+			Namespace:   remediation.Namespace,
+			Labels:      labels,
+			Annotations: script.Annotations,
+		},
+		Spec: spec,
+	}, nil
+}
+
+// ScriptJobName returns the name of the Job for a Remediation. It is
+// deterministic so a retried reconcile finds the Job it already created.
+// Names too long to fit keep a hash of the full name, so Remediations
+// sharing a long prefix get different Jobs.
+func ScriptJobName(remediationName string) string {
+	const suffix = "-script"
+	const hashLength = 10
+	// Job names end up in pod labels, which are limited to 63 characters
+	if len(remediationName) <= 63-len(suffix) {
+		return remediationName + suffix
+	}
+	sum := sha256.Sum256([]byte(remediationName))
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+	prefix := strings.TrimRight(remediationName[:63-len(suffix)-hashLength-1], "-.")
+	return prefix + "-" + hash + suffix
+}
+
+// ScriptEnv returns the environment variables describing remediation's alert
+// and target. Alert labels and annotations become HEAL8S_ALERT_LABEL_<NAME>
+// and HEAL8S_ALERT_ANNOTATION_<NAME>, with NAME upper-cased and characters
+// other than letters and digits replaced by underscores.
+func ScriptEnv(remediation *k8shealerv1alpha1.Remediation) ([]corev1.EnvVar, error) {
+	labels, annotations, err := DecodeAlertPayload(remediation.Spec.Alert.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := remediation.Spec
+	env := []corev1.EnvVar{
+		{Name: EnvPrefix + "REMEDIATION", Value: remediation.Name},
+		{Name: EnvPrefix + "REMEDIATION_NAMESPACE", Value: remediation.Namespace},
+		{Name: EnvPrefix + "ALERT_NAME", Value: spec.Alert.Name},
+		{Name: EnvPrefix + "ALERT_FINGERPRINT", Value: spec.Alert.Fingerprint},
+		{Name: EnvPrefix + "ALERT_SEVERITY", Value: spec.Alert.Severity},
+		{Name: EnvPrefix + "ALERT_SOURCE", Value: spec.Alert.Source},
+		{Name: EnvPrefix + "TARGET_KIND", Value: spec.Target.Kind},
+		{Name: EnvPrefix + "TARGET_NAME", Value: spec.Target.Name},
+		{Name: EnvPrefix + "TARGET_NAMESPACE", Value: spec.Target.Namespace},
+		{Name: EnvPrefix + "TARGET_CONTAINER", Value: spec.Target.Container},
+	}
+	env = append(env, prefixedEnv(EnvPrefix+"ALERT_LABEL_", labels)...)
+	env = append(env, prefixedEnv(EnvPrefix+"ALERT_ANNOTATION_", annotations)...)
+	return env, nil
+}
+
+// DecodeAlertPayload returns the labels and annotations of the alert
+// recorded in a Remediation's spec.alert.payload
+func DecodeAlertPayload(payload string) (labels, annotations map[string]string, err error) {
+	if payload == "" {
+		return nil, nil, nil
+	}
+	var alert struct {
+		Labels      map[string]string `json:"labels"`
+		Annotations map[string]string `json:"annotations"`
+	}
+	if err := json.Unmarshal([]byte(payload), &alert); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode alert payload: %w", err)
+	}
+	return alert.Labels, alert.Annotations, nil
+}
+
+func prefixedEnv(prefix string, values map[string]string) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, len(values))
+	for key, value := range values {
+		env = append(env, corev1.EnvVar{Name: prefix + envName(key), Value: value})
+	}
+	sort.Slice(env, func(i, j int) bool { return env[i].Name < env[j].Name })
+	return env
+}
+
+func envName(key string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key)
+}
+
+// mergeEnv returns existing with any variables named in injected replaced by
+// the injected values
+func mergeEnv(existing, injected []corev1.EnvVar) []corev1.EnvVar {
+	names := make(map[string]bool, len(injected))
+	for _, e := range injected {
+		names[e.Name] = true
+	}
+	merged := make([]corev1.EnvVar, 0, len(existing)+len(injected))
+	for _, e := range existing {
+		if !names[e.Name] {
+			merged = append(merged, e)
+		}
+	}
+	return append(merged, injected...)
+}
@@ -0,0 +1,269 @@
+package remediate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const scriptJobManifest = `apiVersion: batch/v1
+kind: Job
+metadata:
+  labels:
+    team: sre
+spec:
+  backoffLimit: 1
+  template:
+    spec:
+      serviceAccountName: cache-flusher
+      containers:
+      - name: flush
+        image: redis:7
+        env:
+        - name: HEAL8S_TARGET_NAME
+          value: overridden
+        - name: KEEP
+          value: "1"
+`
+
+func TestLoadScriptJob(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	template := &k8shealerv1alpha1.RemediationJobTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "flush-cache", Namespace: "ops"},
+		Spec: k8shealerv1alpha1.RemediationJobTemplateSpec{
+			ServiceAccountName: "cache-flusher",
+			JobTemplate: batchv1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "flush", Image: "redis:7"}}},
+					},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "flush-job", Namespace: "ops"},
+		Data:       map[string]string{JobConfigMapKey: scriptJobManifest},
+	}
+	badConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "not-a-job", Namespace: "ops"},
+		Data:       map[string]string{JobConfigMapKey: "kind: Pod\n"},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(template, configMap, badConfigMap).Build()
+
+	tests := []struct {
+		name                 string
+		params               map[string]string
+		expectError          bool
+		expectServiceAccount string
+	}{
+		{
+			name:                 "job template",
+			params:               map[string]string{ParamJobTemplate: "flush-cache"},
+			expectServiceAccount: "cache-flusher",
+		},
+		{
+			name:                 "configmap",
+			params:               map[string]string{ParamJobConfigMap: "flush-job"},
+			expectServiceAccount: "cache-flusher",
+		},
+		{
+			name:        "missing template",
+			params:      map[string]string{ParamJobTemplate: "missing"},
+			expectError: true,
+		},
+		{
+			name:        "configmap with another kind",
+			params:      map[string]string{ParamJobConfigMap: "not-a-job"},
+			expectError: true,
+		},
+		{
+			name:        "both set",
+			params:      map[string]string{ParamJobTemplate: "flush-cache", ParamJobConfigMap: "flush-job"},
+			expectError: true,
+		},
+		{
+			name:        "neither set",
+			params:      map[string]string{},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script, err := LoadScriptJob(context.Background(), cl, "ops", tt.params)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := script.Spec.Template.Spec.ServiceAccountName; got != tt.expectServiceAccount {
+				t.Errorf("Expected service account %q, got %q", tt.expectServiceAccount, got)
+			}
+		})
+	}
+}
+
+func TestBuildScriptJob(t *testing.T) {
+	remediation := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{Name: "rem-redis", Namespace: "ops"},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Alert: k8shealerv1alpha1.AlertInfo{
+				Name:     "RedisMemoryHigh",
+				Severity: "warning",
+				Source:   "alertmanager",
+				Payload:  `{"labels":{"alertname":"RedisMemoryHigh","k8s-app":"redis"},"annotations":{"runbook_url":"https://runbooks/redis"}}`,
+			},
+			Target: k8shealerv1alpha1.TargetResource{Kind: "StatefulSet", Name: "redis", Namespace: "cache"},
+			Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeCustomScript},
+		},
+	}
+
+	script := &ScriptJob{
+		Source: "ConfigMap flush-job",
+		Labels: map[string]string{"team": "sre"},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					ServiceAccountName: "cache-flusher",
+					InitContainers:     []corev1.Container{{Name: "wait", Image: "busybox"}},
+					Containers: []corev1.Container{{
+						Name:  "flush",
+						Image: "redis:7",
+						Env: []corev1.EnvVar{
+							{Name: "HEAL8S_TARGET_NAME", Value: "overridden"},
+							{Name: "KEEP", Value: "1"},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	job, err := BuildScriptJob(remediation, script)
+	if err != nil {
+		t.Fatalf("BuildScriptJob failed: %v", err)
+	}
+
+	if job.Name != "rem-redis-script" || job.Namespace != "ops" {
+		t.Errorf("Expected job ops/rem-redis-script, got %s/%s", job.Namespace, job.Name)
+	}
+	if job.Labels[LabelRemediation] != "rem-redis" || job.Labels["team"] != "sre" {
+		t.Errorf("Unexpected job labels: %v", job.Labels)
+	}
+	if job.Spec.Template.Labels[LabelRemediation] != "rem-redis" {
+		t.Errorf("Expected pod template to carry the remediation label, got %v", job.Spec.Template.Labels)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyNever {
+		t.Errorf("Expected restart policy Never, got %s", job.Spec.Template.Spec.RestartPolicy)
+	}
+	if script.Spec.Template.Spec.RestartPolicy != "" {
+		t.Errorf("Expected the loaded script to be left unchanged")
+	}
+
+	env := map[string]string{}
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		if _, dup := env[e.Name]; dup {
+			t.Errorf("Duplicate env var %s", e.Name)
+		}
+		env[e.Name] = e.Value
+	}
+	expected := map[string]string{
+		"KEEP":                                "1",
+		"HEAL8S_REMEDIATION":                  "rem-redis",
+		"HEAL8S_ALERT_NAME":                   "RedisMemoryHigh",
+		"HEAL8S_ALERT_SEVERITY":               "warning",
+		"HEAL8S_TARGET_KIND":                  "StatefulSet",
+		"HEAL8S_TARGET_NAME":                  "redis",
+		"HEAL8S_TARGET_NAMESPACE":             "cache",
+		"HEAL8S_ALERT_LABEL_K8S_APP":          "redis",
+		"HEAL8S_ALERT_ANNOTATION_RUNBOOK_URL": "https://runbooks/redis",
+	}
+	for name, value := range expected {
+		if env[name] != value {
+			t.Errorf("Expected %s=%q, got %q", name, value, env[name])
+		}
+	}
+
+	initEnv := job.Spec.Template.Spec.InitContainers[0].Env
+	if len(initEnv) == 0 || initEnv[0].Name != "HEAL8S_REMEDIATION" {
+		t.Errorf("Expected init containers to get the env vars, got %v", initEnv)
+	}
+}
+
+func TestBuildScriptJob_RequiresDedicatedServiceAccount(t *testing.T) {
+	remediation := &k8shealerv1alpha1.Remediation{ObjectMeta: metav1.ObjectMeta{Name: "rem", Namespace: "ops"}}
+
+	for _, sa := range []string{"", "default"} {
+		script := &ScriptJob{
+			Source: "RemediationJobTemplate flush",
+			Spec: batchv1.JobSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				ServiceAccountName: sa,
+				Containers:         []corev1.Container{{Name: "flush", Image: "redis:7"}},
+			}}},
+		}
+		if _, err := BuildScriptJob(remediation, script); err == nil {
+			t.Errorf("Expected error for service account %q", sa)
+		}
+	}
+}
+
+func TestScriptJobName(t *testing.T) {
+	if got := ScriptJobName("rem-a"); got != "rem-a-script" {
+		t.Errorf("Expected rem-a-script, got %s", got)
+	}
+	long := ScriptJobName(strings.Repeat("a", 55) + "-" + strings.Repeat("b", 10))
+	if len(long) > 63 || !strings.HasSuffix(long, "-script") {
+		t.Errorf("Expected a name of at most 63 characters ending in -script, got %q", long)
+	}
+	// Names differing only past the cut must not share a Job
+	other := ScriptJobName(strings.Repeat("a", 55) + "-" + strings.Repeat("c", 10))
+	if other == long {
+		t.Errorf("Expected different Job names for different Remediations, both got %q", long)
+	}
+	if exact := ScriptJobName(strings.Repeat("a", 56)); exact != strings.Repeat("a", 56)+"-script" {
+		t.Errorf("Expected a name that fits to be kept, got %q", exact)
+	}
+}
+
+func TestRouteRule_ValidateCustomScript(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]string
+		expectError bool
+	}{
+		{name: "job template", params: map[string]string{ParamJobTemplate: "flush-cache"}},
+		{name: "configmap", params: map[string]string{ParamJobConfigMap: "flush-job"}},
+		{name: "neither", params: map[string]string{}, expectError: true},
+		{name: "both", params: map[string]string{ParamJobTemplate: "a", ParamJobConfigMap: "b"}, expectError: true},
+		{name: "invalid name", params: map[string]string{ParamJobTemplate: "Flush_Cache"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := RouteRule{Name: "script", ActionType: ActionTypeCustomScript, Params: tt.params}
+			err := rule.Validate()
+			if tt.expectError && err == nil {
+				t.Errorf("Expected error but got none")
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remediate
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+// ParamTemplateData is what route param templates are evaluated against, e.g.
+// {{ .Annotations.max_memory }} or {{ .Labels.maxReplicas }}
+type ParamTemplateData struct {
+	Labels      map[string]string
+	Annotations map[string]string
+	// Target is the target derived from the alert labels, with a Pod
+	// resolved to its owning workload when the owner can be found
+	Target      k8shealerv1alpha1.TargetResource
+	Fingerprint string
+	Source      string
+}
+
+// paramTemplateFuncs are the helpers available to param templates
+var paramTemplateFuncs = template.FuncMap{
+	"quantity":    parseQuantityString,
+	"addQuantity": addQuantity,
+	"subQuantity": subQuantity,
+	"mulQuantity": mulQuantity,
+	"maxQuantity": maxQuantity,
+	"minQuantity": minQuantity,
+	"int":         toInt,
+	"default":     defaultValue,
+	"required":    required,
+}
+
+// isParamTemplate reports whether a param value is a template
+func isParamTemplate(value string) bool {
+	return strings.Contains(value, "{{")
+}
+
+func parseParamTemplate(key, value string) (*template.Template, error) {
+	// Missing labels and annotations render as "" so default and required work
+	tmpl, err := template.New(key).Funcs(paramTemplateFuncs).Option("missingkey=zero").Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("param %s: invalid template: %w", key, err)
+	}
+	return tmpl, nil
+}
+
+// renderParams evaluates templated params against data and validates the
+// results. Plain values are copied unchanged.
+func renderParams(params map[string]string, data ParamTemplateData) (map[string]string, error) {
+	rendered := make(map[string]string, len(params))
+	for key, value := range params {
+		if !isParamTemplate(value) {
+			rendered[key] = value
+			continue
+		}
+
+		tmpl, err := parseParamTemplate(key, value)
+		if err != nil {
+			return nil, err
+		}
+		var out strings.Builder
+		if err := tmpl.Execute(&out, data); err != nil {
+			return nil, fmt.Errorf("param %s: %w", key, err)
+		}
+		result := strings.TrimSpace(out.String())
+		if result == "" {
+			return nil, fmt.Errorf("param %s: template %q rendered an empty value", key, value)
+		}
+		rendered[key] = result
+	}
+
+	if err := ValidateParams(rendered); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+func parseQuantityString(s string) (string, error) {
+	q, err := parseQuantity(s)
+	if err != nil {
+		return "", err
+	}
+	return q.String(), nil
+}
+
+func parseQuantity(s string) (resource.Quantity, error) {
+	q, err := resource.ParseQuantity(strings.TrimSpace(s))
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("%q is not a valid quantity", s)
+	}
+	return q, nil
+}
+
+func addQuantity(a, b string) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	qa.Add(qb)
+	return qa.String(), nil
+}
+
+func subQuantity(a, b string) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	qa.Sub(qb)
+	return qa.String(), nil
+}
+
+// mulQuantity multiplies a quantity by factor, rounding up. The quantity
+// comes last so it can be piped: {{ .Annotations.limit | mulQuantity 1.5 }}.
+func mulQuantity(factor interface{}, q string) (string, error) {
+	quantity, err := parseQuantity(q)
+	if err != nil {
+		return "", err
+	}
+	f, err := toFloat(factor)
+	if err != nil {
+		return "", err
+	}
+	if f < 0 {
+		return "", fmt.Errorf("mulQuantity: negative factor %v", f)
+	}
+	// Work in milli-units so CPU quantities keep their precision
+	milli := math.Ceil(float64(quantity.MilliValue()) * f)
+	result := resource.NewMilliQuantity(int64(milli), quantity.Format)
+	return result.String(), nil
+}
+
+func maxQuantity(a, b string) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	if qa.Cmp(qb) >= 0 {
+		return qa.String(), nil
+	}
+	return qb.String(), nil
+}
+
+func minQuantity(a, b string) (string, error) {
+	qa, err := parseQuantity(a)
+	if err != nil {
+		return "", err
+	}
+	qb, err := parseQuantity(b)
+	if err != nil {
+		return "", err
+	}
+	if qa.Cmp(qb) <= 0 {
+		return qa.String(), nil
+	}
+	return qb.String(), nil
+}
+
+// toInt converts label values such as "10" or "10.0" to an integer
+func toInt(v interface{}) (int, error) {
+	f, err := toFloat(v)
+	if err != nil {
+		return 0, err
+	}
+	return int(math.Round(f)), nil
+}
+
+func toFloat(v interface{}) (float64, error) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a number", n)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("%v is not a number", v)
+	}
+}
+
+// defaultValue returns value, or def if value is empty. Used as
+// {{ .Annotations.max_memory | default "2Gi" }}.
+func defaultValue(def, value string) string {
+	if value == "" {
+		return def
+	}
+	return value
+}
+
+// required fails the template with message if value is empty
+func required(message, value string) (string, error) {
+	if value == "" {
+		return "", fmt.Errorf("%s", message)
+	}
+	return value, nil
+}
@@ -0,0 +1,169 @@
+package remediate
+
+import (
+	"strings"
+	"testing"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func TestRouteAlert_TemplatedParams(t *testing.T) {
+	alert := Alert{
+		Labels: map[string]string{
+			"alertname":    "KubeHpaMaxedOut",
+			"namespace":    "prod",
+			"deployment":   "api",
+			"max_replicas": "12",
+		},
+		Annotations: map[string]string{
+			"max_memory": "3Gi",
+		},
+		Status:      "firing",
+		Fingerprint: "abc123",
+	}
+
+	tests := []struct {
+		name        string
+		params      map[string]string
+		expected    map[string]string
+		expectError string
+	}{
+		{
+			name:     "label and annotation values",
+			params:   map[string]string{"maxReplicas": "{{ .Labels.max_replicas | int }}", "maxMemory": "{{ .Annotations.max_memory }}"},
+			expected: map[string]string{"maxReplicas": "12", "maxMemory": "3Gi"},
+		},
+		{
+			name:     "quantity arithmetic",
+			params:   map[string]string{"maxMemory": `{{ .Annotations.max_memory | mulQuantity 1.5 | minQuantity "4Gi" }}`},
+			expected: map[string]string{"maxMemory": "4Gi"},
+		},
+		{
+			name:     "add and subtract",
+			params:   map[string]string{"maxMemory": `{{ subQuantity (addQuantity .Annotations.max_memory "1Gi") "512Mi" }}`},
+			expected: map[string]string{"maxMemory": "3584Mi"},
+		},
+		{
+			name:     "cpu precision",
+			params:   map[string]string{"maxCPU": `{{ mulQuantity 1.25 "300m" }}`},
+			expected: map[string]string{"maxCPU": "375m"},
+		},
+		{
+			name:     "default for missing annotation",
+			params:   map[string]string{"maxMemory": `{{ .Annotations.memory_cap | default "2Gi" }}`},
+			expected: map[string]string{"maxMemory": "2Gi"},
+		},
+		{
+			name:     "target fields",
+			params:   map[string]string{"note": "{{ .Target.Kind }}/{{ .Target.Name }}"},
+			expected: map[string]string{"note": "Deployment/api"},
+		},
+		{
+			name:     "static values unchanged",
+			params:   map[string]string{"scaleUpPercent": "50"},
+			expected: map[string]string{"scaleUpPercent": "50"},
+		},
+		{
+			name:        "required value missing",
+			params:      map[string]string{"maxMemory": `{{ required "alert has no memory_cap annotation" .Annotations.memory_cap }}`},
+			expectError: "alert has no memory_cap annotation",
+		},
+		{
+			name:        "empty result",
+			params:      map[string]string{"maxMemory": "{{ .Annotations.memory_cap }}"},
+			expectError: "rendered an empty value",
+		},
+		{
+			name:        "rendered value fails validation",
+			params:      map[string]string{"maxReplicas": "{{ .Annotations.max_memory }}"},
+			expectError: "not an integer",
+		},
+		{
+			name:        "helper error",
+			params:      map[string]string{"maxMemory": `{{ addQuantity .Labels.max_replicas "lots" }}`},
+			expectError: "not a valid quantity",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := RouterConfig{Rules: []RouteRule{{
+				Name:       "hpa",
+				ActionType: ActionTypeScaleUp,
+				Params:     tt.params,
+			}}}
+			if err := config.Validate(); err != nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+
+			results, err := RouteAlert(alert, config, nil)
+			if tt.expectError != "" {
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+				if !strings.Contains(err.Error(), tt.expectError) || !strings.Contains(err.Error(), `rule "hpa"`) {
+					t.Errorf("expected error mentioning the rule and %q, got %v", tt.expectError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			params := results[0].Spec.Action.Params
+			for key, value := range tt.expected {
+				if params[key] != value {
+					t.Errorf("param %s: expected %q, got %q", key, value, params[key])
+				}
+			}
+		})
+	}
+}
+
+func TestRouteAlert_TemplatesSeeResolvedTarget(t *testing.T) {
+	alert := Alert{
+		Labels: map[string]string{
+			"alertname": "KubePodOOMKilled",
+			"namespace": "prod",
+			"pod":       "api-5f7b8c9d-xyz",
+			"container": "app",
+		},
+		Status: "firing",
+	}
+	config := RouterConfig{Rules: []RouteRule{{
+		Name:       "oom",
+		ActionType: ActionTypeIncreaseMemory,
+		Params:     map[string]string{"note": "{{ .Target.Kind }}/{{ .Target.Name }}"},
+	}}}
+	resolve := func(target *k8shealerv1alpha1.TargetResource) {
+		if target.Kind == "Pod" {
+			target.Kind, target.Name = "Deployment", "api"
+		}
+	}
+
+	results, err := RouteAlert(alert, config, resolve)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if note := results[0].Spec.Action.Params["note"]; note != "Deployment/api" {
+		t.Errorf("expected the template to see the resolved target, got %q", note)
+	}
+	if target := results[0].Spec.Target; target.Kind != "Deployment" || target.Name != "api" || target.Container != "app" {
+		t.Errorf("expected the spec to carry the resolved target, got %+v", target)
+	}
+}
+
+func TestRouteRule_ValidateTemplate(t *testing.T) {
+	rule := RouteRule{
+		Name:       "broken",
+		ActionType: ActionTypeIncreaseMemory,
+		Params:     map[string]string{"maxMemory": "{{ .Annotations.max_memory "},
+	}
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for malformed template")
+	}
+
+	rule.Params = map[string]string{"maxMemory": "{{ unknownFunc 1 }}"}
+	if err := rule.Validate(); err == nil {
+		t.Error("expected error for unknown function")
+	}
+}
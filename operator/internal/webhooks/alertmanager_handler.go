@@ -19,15 +19,18 @@ package webhooks
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
@@ -59,54 +62,74 @@ type AlertPayload struct {
 	Fingerprint  string            `json:"fingerprint"`
 }
 
-// AlertDeduplicator handles alert deduplication
-type AlertDeduplicator struct {
-	mu   sync.RWMutex
-	seen map[string]time.Time
-	ttl  time.Duration
+// RouterConfigSource provides the routing configuration currently in effect
+type RouterConfigSource interface {
+	RouterConfig() remediate.RouterConfig
 }
 
-// NewAlertDeduplicator creates a new deduplicator
-func NewAlertDeduplicator(ttl time.Duration) *AlertDeduplicator {
-	d := &AlertDeduplicator{
-		seen: make(map[string]time.Time),
-		ttl:  ttl,
-	}
+// StaticRouterConfig is a RouterConfigSource that always returns the same config
+type StaticRouterConfig remediate.RouterConfig
 
-	// Start cleanup goroutine
-	go d.cleanup()
+// RouterConfig returns the wrapped configuration
+func (c StaticRouterConfig) RouterConfig() remediate.RouterConfig {
+	return remediate.RouterConfig(c)
+}
 
-	return d
+// ScopeSource provides the namespace scope currently in effect
+type ScopeSource interface {
+	Scope() remediate.Scope
 }
 
-// ShouldProcess checks if the alert should be processed
-func (d *AlertDeduplicator) ShouldProcess(fingerprint string, startsAt time.Time) bool {
-	key := fmt.Sprintf("%s:%s", fingerprint, startsAt.Format(time.RFC3339))
+// StaticScope is a ScopeSource that always returns the same scope
+type StaticScope remediate.Scope
 
-	d.mu.Lock()
-	defer d.mu.Unlock()
+// Scope returns the wrapped scope
+func (s StaticScope) Scope() remediate.Scope {
+	return remediate.Scope(s)
+}
 
-	if lastSeen, ok := d.seen[key]; ok && time.Since(lastSeen) < d.ttl {
-		return false
-	}
+// DefaultMaxBodyBytes is the default request body size limit for webhook requests
+const DefaultMaxBodyBytes int64 = 1 << 20
 
-	d.seen[key] = time.Now()
-	return true
-}
+// HandlerOptions configures an AlertmanagerHandler
+type HandlerOptions struct {
+	// Routes supplies the routing configuration. Defaults to remediate.DefaultRouterConfig().
+	Routes RouterConfigSource
 
-func (d *AlertDeduplicator) cleanup() {
-	ticker := time.NewTicker(5 * time.Minute)
-	defer ticker.Stop()
+	// Scope limits the namespaces alerts are remediated in. Defaults to all namespaces.
+	Scope ScopeSource
 
-	for range ticker.C {
-		d.mu.Lock()
-		for key, lastSeen := range d.seen {
-			if time.Since(lastSeen) > d.ttl {
-				delete(d.seen, key)
-			}
-		}
-		d.mu.Unlock()
-	}
+	// RateLimits bounds how often Remediations are created. Defaults to no limits.
+	RateLimits RateLimitSource
+
+	// MaxBodyBytes limits the size of a request body. Defaults to DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+
+	// DedupWindow is how long a Remediation suppresses repeat notifications of
+	// the same alert instance. Defaults to DefaultDedupWindow.
+	DedupWindow time.Duration
+
+	// QueueSize is the number of alerts that can wait for a worker before
+	// webhook requests are rejected. Defaults to DefaultQueueSize.
+	QueueSize int
+
+	// Workers is the number of alerts processed concurrently. Defaults to DefaultWorkers.
+	Workers int
+
+	// DrainTimeout bounds how long shutdown waits for queued alerts. Defaults to DefaultDrainTimeout.
+	DrainTimeout time.Duration
+
+	// RetryBackoff is used to retry transient API errors. Defaults to DefaultRetryBackoff.
+	RetryBackoff *wait.Backoff
+
+	// RemediationNamespace, if set, is the namespace all Remediations are
+	// created in. By default a Remediation lives in its target's namespace.
+	RemediationNamespace string
+
+	// CoalesceWindow is how long a Remediation absorbs further alerts for the
+	// same target and action. Defaults to DefaultCoalesceWindow; a negative
+	// value disables coalescing.
+	CoalesceWindow time.Duration
 }
 
 // AlertmanagerHandler handles Alertmanager webhook requests
@@ -115,88 +138,210 @@ type AlertmanagerHandler struct {
 	scheme       *runtime.Scheme
 	logger       logr.Logger
 	dedup        *AlertDeduplicator
-	routerConfig remediate.RouterConfig
+	routes       RouterConfigSource
+	scope        ScopeSource
+	maxBodyBytes int64
+	queue        *alertQueue
+	retryBackoff wait.Backoff
+	coalescer    *alertCoalescer
+	rateLimiter  *rateLimiter
+	// remediationNamespace is the central namespace for Remediations, if any
+	remediationNamespace string
 }
 
 // NewAlertmanagerHandler creates a new Alertmanager webhook handler
-func NewAlertmanagerHandler(client client.Client, scheme *runtime.Scheme, logger logr.Logger) *AlertmanagerHandler {
+func NewAlertmanagerHandler(client client.Client, scheme *runtime.Scheme, logger logr.Logger, opts HandlerOptions) *AlertmanagerHandler {
+	if opts.Routes == nil {
+		opts.Routes = StaticRouterConfig(remediate.DefaultRouterConfig())
+	}
+	if opts.Scope == nil {
+		opts.Scope = StaticScope{}
+	}
+	if opts.RateLimits == nil {
+		opts.RateLimits = StaticRateLimits{}
+	}
+	if opts.MaxBodyBytes <= 0 {
+		opts.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+	if opts.DedupWindow <= 0 {
+		opts.DedupWindow = DefaultDedupWindow
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = DefaultQueueSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
+	}
+	if opts.DrainTimeout <= 0 {
+		opts.DrainTimeout = DefaultDrainTimeout
+	}
+	if opts.RetryBackoff == nil {
+		opts.RetryBackoff = &DefaultRetryBackoff
+	}
+	if opts.CoalesceWindow == 0 {
+		opts.CoalesceWindow = DefaultCoalesceWindow
+	}
+
 	return &AlertmanagerHandler{
-		client:       client,
-		scheme:       scheme,
-		logger:       logger,
-		dedup:        NewAlertDeduplicator(1 * time.Hour),
-		routerConfig: remediate.DefaultRouterConfig(),
+		client:               client,
+		scheme:               scheme,
+		logger:               logger,
+		dedup:                NewAlertDeduplicator(client, opts.DedupWindow),
+		routes:               opts.Routes,
+		scope:                opts.Scope,
+		maxBodyBytes:         opts.MaxBodyBytes,
+		queue:                newAlertQueue(logger, opts.QueueSize, opts.Workers, opts.DrainTimeout),
+		retryBackoff:         *opts.RetryBackoff,
+		coalescer:            newAlertCoalescer(client, opts.CoalesceWindow, opts.RemediationNamespace, *opts.RetryBackoff),
+		rateLimiter:          newRateLimiter(client, opts.RateLimits, opts.RemediationNamespace),
+		remediationNamespace: opts.RemediationNamespace,
 	}
 }
 
+// Start runs the alert processing workers until ctx is cancelled, then drains
+// the queue. It implements manager.Runnable so the manager owns its lifecycle.
+func (h *AlertmanagerHandler) Start(ctx context.Context) error {
+	return h.queue.start(ctx)
+}
+
+// NeedLeaderElection returns false: every replica serves the webhook, and
+// Remediation creation is deduplicated through the API server
+func (h *AlertmanagerHandler) NeedLeaderElection() bool {
+	return false
+}
+
 // HandleWebhook handles incoming Alertmanager webhook requests
 func (h *AlertmanagerHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
-		return
-	}
-
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		h.logger.Error(err, "Failed to read request body")
-		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+	body, ok := h.readBody(w, r)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
 
 	var payload AlertmanagerWebhookPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
 		h.logger.Error(err, "Failed to parse webhook payload")
+		metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
 		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
 		return
 	}
-
 	h.logger.Info("Received Alertmanager webhook",
 		"receiver", payload.Receiver,
 		"status", payload.Status,
 		"alertCount", len(payload.Alerts))
 
-	// Process each alert
-	for _, alert := range payload.Alerts {
-		alertname := alert.Labels["alertname"]
-		severity := alert.Labels["severity"]
+	h.enqueueAlerts(w, remediate.SourceAlertmanager, payload.Alerts)
+}
 
-		// Track received alert
-		metrics.AlertsReceived.WithLabelValues(alertname, severity).Inc()
+// readBody reads the body of a webhook POST request within the size limit.
+// On failure the response has been written and false is returned.
+func (h *AlertmanagerHandler) readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	if r.Method != http.MethodPost {
+		metrics.WebhookRequestsRejected.WithLabelValues("method-not-allowed").Inc()
+		http.Error(w, "Only POST method is allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
 
-		// Only process firing alerts
-		if alert.Status != "firing" {
-			h.logger.Info("Skipping non-firing alert",
-				"alertname", alertname,
-				"status", alert.Status)
-			metrics.AlertsSkipped.WithLabelValues(alertname, "not-firing").Inc()
-			continue
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			h.logger.Info("Rejected oversized webhook request", "limit", maxBytesErr.Limit)
+			metrics.WebhookRequestsRejected.WithLabelValues("too-large").Inc()
+			http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+			return nil, false
 		}
+		h.logger.Error(err, "Failed to read request body")
+		metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+	defer r.Body.Close()
 
-		// Check deduplication
-		if !h.dedup.ShouldProcess(alert.Fingerprint, alert.StartsAt) {
-			h.logger.Info("Skipping duplicate alert",
-				"alertname", alertname,
-				"fingerprint", alert.Fingerprint)
-			metrics.AlertsSkipped.WithLabelValues(alertname, "duplicate").Inc()
-			continue
-		}
+	return body, true
+}
 
-		// Process alert asynchronously
-		go h.processAlert(alert, payload)
+// enqueueAlerts queues the alerts of a webhook request and writes the response.
+// If the queue is full the request is rejected with 503 so the sender retries.
+func (h *AlertmanagerHandler) enqueueAlerts(w http.ResponseWriter, source string, alerts []AlertPayload) {
+	for _, alert := range alerts {
+		if err := h.EnqueueAlert(source, alert); err != nil {
+			// Senders retry the whole notification on 5xx; alerts already
+			// queued are deduplicated when they are seen again
+			h.logger.Info("Rejecting webhook, alert queue unavailable", "source", source, "reason", err.Error())
+			metrics.WebhookRequestsRejected.WithLabelValues("queue-full").Inc()
+			w.Header().Set("Retry-After", "10")
+			http.Error(w, "Alert queue is full, retry later", http.StatusServiceUnavailable)
+			return
+		}
 	}
 
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
 }
 
-func (h *AlertmanagerHandler) processAlert(alert AlertPayload, payload AlertmanagerWebhookPayload) {
-	ctx := context.Background()
+// EnqueueAlert queues an alert from source for processing. Firing alerts
+// create Remediations, resolved alerts close them out and anything else is
+// skipped. ErrQueueFull or ErrQueueClosed is returned if the alert could not
+// be queued.
+func (h *AlertmanagerHandler) EnqueueAlert(source string, alert AlertPayload) error {
+	alertname := alert.Labels["alertname"]
+
+	// Track received alert
+	metrics.AlertsReceived.WithLabelValues(source, alertname, alert.Labels["severity"]).Inc()
+
+	var task alertTask
+	switch alert.Status {
+	case "resolved":
+		// Resolved alerts close out remediations created for them
+		task = alertTask{
+			status:    alert.Status,
+			alertname: alertname,
+			process: func(ctx context.Context) error {
+				return retry.OnError(h.retryBackoff, isTransientError, func() error {
+					return h.processResolvedAlert(ctx, alert)
+				})
+			},
+		}
+	case "firing":
+		task = alertTask{
+			status:    alert.Status,
+			alertname: alertname,
+			process: func(ctx context.Context) error {
+				return h.processAlert(ctx, source, alert)
+			},
+		}
+	default:
+		h.logger.Info("Skipping non-firing alert",
+			"alertname", alertname,
+			"status", alert.Status)
+		metrics.AlertsSkipped.WithLabelValues(source, alertname, "not-firing").Inc()
+		return nil
+	}
+
+	return h.queue.enqueue(task)
+}
+
+func (h *AlertmanagerHandler) processAlert(ctx context.Context, source string, alert AlertPayload) error {
 	logger := h.logger.WithValues(
 		"alertname", alert.Labels["alertname"],
 		"fingerprint", alert.Fingerprint,
 	)
 
+	// Check deduplication against existing Remediations
+	var duplicate bool
+	err := retry.OnError(h.retryBackoff, isTransientError, func() (err error) {
+		duplicate, err = h.dedup.IsDuplicate(ctx, alert.Fingerprint, alert.StartsAt)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if duplicate {
+		logger.Info("Skipping duplicate alert")
+		metrics.AlertsSkipped.WithLabelValues(source, alert.Labels["alertname"], "duplicate").Inc()
+		return nil
+	}
+
 	logger.Info("Processing alert")
 
 	// Convert to remediate.Alert
@@ -206,55 +351,175 @@ func (h *AlertmanagerHandler) processAlert(alert AlertPayload, payload Alertmana
 		Status:      alert.Status,
 		StartsAt:    alert.StartsAt.Format(time.RFC3339),
 		Fingerprint: alert.Fingerprint,
+		Source:      source,
+	}
+
+	// Route alert to remediation specs (one per matching rule)
+	results, err := remediate.RouteAlert(remAlert, h.routes.RouterConfig(), func(target *k8shealerv1alpha1.TargetResource) {
+		h.resolveTarget(ctx, logger, target)
+	})
+	if errors.Is(err, remediate.ErrNoRoute) {
+		logger.Info("Skipping alert without a matching route")
+		metrics.AlertsSkipped.WithLabelValues(source, alert.Labels["alertname"], "no-route").Inc()
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to route alert: %w", err)
 	}
 
-	// Route alert to remediation spec
-	spec, err := remediate.RouteAlert(remAlert, h.routerConfig)
+	var errs []error
+	for _, result := range results {
+		var optOut string
+		err := retry.OnError(h.retryBackoff, isTransientError, func() (err error) {
+			optOut, err = h.checkScope(ctx, logger, result.Spec)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if optOut != "" {
+			logger.Info("Skipping opted-out target", "rule", result.Rule, "reason", optOut)
+			metrics.AlertsSkipped.WithLabelValues(source, alert.Labels["alertname"], "opted-out").Inc()
+			continue
+		}
+
+		remediation := buildRemediation(alert, remediationName(alert, result.Rule, result.Spec.Target),
+			remediationNamespace(h.remediationNamespace, result.Spec.Target), result)
+
+		err = retry.OnError(h.retryBackoff, isTransientError, func() error {
+			return h.coalesceOrCreate(ctx, logger.WithValues("rule", result.Rule), alert, remediation)
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// resolveTarget replaces a Pod target with its owning workload so that alerts
+// for different pods of one workload coalesce. If the owner cannot be
+// resolved the Pod target is kept and the controller reports the failure.
+func (h *AlertmanagerHandler) resolveTarget(ctx context.Context, logger logr.Logger, target *k8shealerv1alpha1.TargetResource) {
+	if target.Kind != "Pod" {
+		return
+	}
+	kind, name, err := remediate.ResolvePodOwner(ctx, h.client, target.Namespace, target.Name)
 	if err != nil {
-		logger.Error(err, "Failed to route alert")
+		logger.Info("Could not resolve pod owner, leaving it to the controller", "pod", target.Name, "error", err.Error())
 		return
 	}
+	target.Kind = kind
+	target.Name = name
+}
+
+// coalesceOrCreate attaches the alert to a recent Remediation for the same
+// target and action, or creates remediation if there is none
+func (h *AlertmanagerHandler) coalesceOrCreate(ctx context.Context, logger logr.Logger, alert AlertPayload, remediation *k8shealerv1alpha1.Remediation) error {
+	if !h.coalescer.enabled() {
+		return h.admitRemediation(ctx, logger, alert, remediation)
+	}
+
+	key := coalesceKey(&remediation.Spec)
+	unlock := h.coalescer.lock(key)
+	defer unlock()
+
+	existing, err := h.coalescer.find(ctx, &remediation.Spec)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.Name != remediation.Name {
+		if err := h.coalescer.attach(ctx, *existing, alert.Fingerprint, alert.StartsAt); err != nil {
+			return fmt.Errorf("failed to coalesce alert into Remediation %s: %w", existing, err)
+		}
+		logger.Info("Coalesced alert into existing Remediation", "name", existing.Name, "namespace", existing.Namespace)
+		metrics.AlertsSkipped.WithLabelValues(remediation.Spec.Alert.Source, alert.Labels["alertname"], "coalesced").Inc()
+		return nil
+	}
+
+	if err := h.admitRemediation(ctx, logger, alert, remediation); err != nil {
+		return err
+	}
+	// Throttled Remediations do not absorb later alerts; those are checked
+	// against the limits again
+	if !remediate.IsThrottled(remediation) {
+		h.coalescer.remember(key, remediation)
+	}
+	return nil
+}
+
+// admitRemediation creates remediation subject to the rate limits. Over
+// budget it is either created as throttled or skipped.
+func (h *AlertmanagerHandler) admitRemediation(ctx context.Context, logger logr.Logger, alert AlertPayload, remediation *k8shealerv1alpha1.Remediation) error {
+	reason, err := h.rateLimiter.admit(ctx, remediation, func() error {
+		return h.createRemediation(ctx, logger, alert, remediation)
+	})
+	if err != nil {
+		return err
+	}
+	if reason != "" {
+		logger.Info("Remediation is over its rate limit", "name", remediation.Name, "reason", reason)
+		metrics.AlertsSkipped.WithLabelValues(remediation.Spec.Alert.Source, alert.Labels["alertname"], "throttled").Inc()
+	}
+	return nil
+}
 
-	// Create Remediation CR name
-	remediationName := fmt.Sprintf("rem-%s-%s",
-		alert.Labels["alertname"],
-		alert.StartsAt.Format("20060102-150405"))
+// remediationNamespace returns the namespace a Remediation for target is
+// created in: the central namespace if one is configured, otherwise the
+// target's own namespace
+func remediationNamespace(central string, target k8shealerv1alpha1.TargetResource) string {
+	if central != "" {
+		return central
+	}
+	return target.Namespace
+}
+
+// buildRemediation builds the Remediation object for a routing result
+func buildRemediation(alert AlertPayload, remediationName, namespace string, result remediate.RouteResult) *k8shealerv1alpha1.Remediation {
+	spec := result.Spec
 
 	// Set payload
 	payloadJSON, _ := json.Marshal(alert)
 	spec.Alert.Payload = string(payloadJSON)
 	spec.Alert.AlertID = fmt.Sprintf("%s-%d", alert.Fingerprint, alert.StartsAt.Unix())
 
-	// Create Remediation object
-	remediation := &k8shealerv1alpha1.Remediation{
+	return &k8shealerv1alpha1.Remediation{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      remediationName,
-			Namespace: spec.Target.Namespace, // Create in same namespace as target
+			Namespace: namespace,
 			Labels: map[string]string{
-				"k8s-healer.io/alert":       alert.Labels["alertname"],
-				"k8s-healer.io/target":      spec.Target.Name,
-				"k8s-healer.io/fingerprint": alert.Fingerprint,
+				"k8s-healer.io/alert": labelValue(alert.Labels["alertname"]),
+				LabelTarget:           spec.Target.Name,
+				LabelTargetKind:       spec.Target.Kind,
+				LabelTargetNamespace:  spec.Target.Namespace,
+				LabelAction:           string(spec.Action.Type),
+				LabelFingerprint:      alert.Fingerprint,
+				LabelStartsAt:         startsAtLabelValue(alert.StartsAt),
+				"k8s-healer.io/route": result.Rule,
 			},
 		},
 		Spec: *spec,
 	}
+}
 
-	// Check if remediation already exists (idempotency)
-	existing := &k8shealerv1alpha1.Remediation{}
-	err = h.client.Get(ctx, client.ObjectKey{
-		Namespace: remediation.Namespace,
-		Name:      remediation.Name,
-	}, existing)
-
-	if err == nil {
-		logger.Info("Remediation already exists, skipping", "name", remediation.Name)
-		return
-	}
+func (h *AlertmanagerHandler) createRemediation(ctx context.Context, logger logr.Logger, alert AlertPayload, remediation *k8shealerv1alpha1.Remediation) error {
+	spec := &remediation.Spec
 
 	// Create the Remediation CR
 	if err := h.client.Create(ctx, remediation); err != nil {
-		logger.Error(err, "Failed to create Remediation CR")
-		return
+		if apierrors.IsAlreadyExists(err) {
+			// Created by another replica or an earlier attempt
+			logger.Info("Remediation already exists, skipping", "name", remediation.Name)
+			metrics.AlertsSkipped.WithLabelValues(spec.Alert.Source, alert.Labels["alertname"], "duplicate").Inc()
+			return nil
+		}
+		return fmt.Errorf("failed to create Remediation %s/%s: %w", remediation.Namespace, remediation.Name, err)
+	}
+
+	if remediate.IsThrottled(remediation) {
+		logger.Info("Created throttled Remediation CR", "name", remediation.Name, "namespace", remediation.Namespace)
+		return nil
 	}
 
 	// Track metrics
@@ -268,4 +533,5 @@ func (h *AlertmanagerHandler) processAlert(alert AlertPayload, payload Alertmana
 		"name", remediation.Name,
 		"namespace", remediation.Namespace,
 		"action", spec.Action.Type)
+	return nil
 }
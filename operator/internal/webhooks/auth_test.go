@@ -0,0 +1,119 @@
+package webhooks
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+func TestRequireAuth(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	tests := []struct {
+		name         string
+		config       AuthConfig
+		setupRequest func(r *http.Request)
+		expectStatus int
+	}{
+		{
+			name:         "no auth configured",
+			config:       AuthConfig{},
+			setupRequest: func(r *http.Request) {},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:   "valid bearer token",
+			config: AuthConfig{BearerToken: "s3cret"},
+			setupRequest: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer s3cret")
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:   "wrong bearer token",
+			config: AuthConfig{BearerToken: "s3cret"},
+			setupRequest: func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer guess")
+			},
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:         "missing bearer token",
+			config:       AuthConfig{BearerToken: "s3cret"},
+			setupRequest: func(r *http.Request) {},
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "basic auth sent when bearer expected",
+			config: AuthConfig{BearerToken: "s3cret"},
+			setupRequest: func(r *http.Request) {
+				r.SetBasicAuth("alertmanager", "s3cret")
+			},
+			expectStatus: http.StatusUnauthorized,
+		},
+		{
+			name:   "valid basic auth",
+			config: AuthConfig{Username: "alertmanager", Password: "pw"},
+			setupRequest: func(r *http.Request) {
+				r.SetBasicAuth("alertmanager", "pw")
+			},
+			expectStatus: http.StatusOK,
+		},
+		{
+			name:   "wrong basic auth password",
+			config: AuthConfig{Username: "alertmanager", Password: "pw"},
+			setupRequest: func(r *http.Request) {
+				r.SetBasicAuth("alertmanager", "nope")
+			},
+			expectStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/alertmanager", nil)
+			tt.setupRequest(req)
+			rec := httptest.NewRecorder()
+
+			RequireAuth(tt.config, ok)(rec, req)
+
+			if rec.Code != tt.expectStatus {
+				t.Errorf("expected status %d, got %d", tt.expectStatus, rec.Code)
+			}
+			if rec.Code == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected WWW-Authenticate header on 401")
+			}
+		})
+	}
+}
+
+func TestAuthConfig_Validate(t *testing.T) {
+	if err := (AuthConfig{BearerToken: "t", Username: "u", Password: "p"}).Validate(); err == nil {
+		t.Error("expected error when both bearer and basic auth are set")
+	}
+	if err := (AuthConfig{Username: "u"}).Validate(); err == nil {
+		t.Error("expected error for basic auth without password")
+	}
+	if err := (AuthConfig{Username: "u", Password: "p"}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestHandleWebhook_BodyLimit(t *testing.T) {
+	handler := NewAlertmanagerHandler(nil, nil, logr.Discard(), HandlerOptions{MaxBodyBytes: 64})
+
+	body := `{"alerts":[],"receiver":"` + strings.Repeat("x", 128) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/alertmanager", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handler.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status %d, got %d", http.StatusRequestEntityTooLarge, rec.Code)
+	}
+}
@@ -0,0 +1,263 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const (
+	// LabelTarget records the name of the target workload
+	LabelTarget = "k8s-healer.io/target"
+
+	// LabelTargetKind records the kind of the target workload
+	LabelTargetKind = "k8s-healer.io/target-kind"
+
+	// LabelTargetNamespace records the namespace of the target workload, so
+	// Remediations in a central namespace can be filtered per team
+	LabelTargetNamespace = "k8s-healer.io/target-namespace"
+
+	// LabelAction records the remediation action type
+	LabelAction = "k8s-healer.io/action"
+
+	// DefaultCoalesceWindow is how long a Remediation absorbs alerts for the
+	// same target and action
+	DefaultCoalesceWindow = 5 * time.Minute
+)
+
+// alertCoalescer folds alerts that resolve to the same target and action into
+// the Remediation created first, so that a burst of per-pod alerts for one
+// workload results in a single change. Lookups go through the API server
+// (via the cache); a per-key lock and a record of recently created
+// Remediations cover the gap before the cache has seen our own creates.
+type alertCoalescer struct {
+	client  client.Client
+	window  time.Duration
+	central string
+	backoff wait.Backoff
+	now     func() time.Time
+
+	mu     sync.Mutex
+	locks  map[string]*keyLock
+	recent map[string]recentRemediation
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+type recentRemediation struct {
+	key     client.ObjectKey
+	created time.Time
+}
+
+func newAlertCoalescer(cl client.Client, window time.Duration, central string, backoff wait.Backoff) *alertCoalescer {
+	return &alertCoalescer{
+		client:  cl,
+		window:  window,
+		central: central,
+		backoff: backoff,
+		now:     time.Now,
+		locks:   map[string]*keyLock{},
+		recent:  map[string]recentRemediation{},
+	}
+}
+
+// enabled reports whether coalescing is turned on
+func (c *alertCoalescer) enabled() bool {
+	return c.window > 0
+}
+
+// coalesceKey identifies remediations that would change the same thing
+func coalesceKey(spec *k8shealerv1alpha1.RemediationSpec) string {
+	return strings.Join([]string{
+		spec.Target.Namespace,
+		spec.Target.Kind,
+		spec.Target.Name,
+		spec.Target.Container,
+		string(spec.Action.Type),
+	}, "/")
+}
+
+// lock serializes coalescing decisions for key and returns the unlock function
+func (c *alertCoalescer) lock(key string) func() {
+	c.mu.Lock()
+	l, ok := c.locks[key]
+	if !ok {
+		l = &keyLock{}
+		c.locks[key] = l
+	}
+	l.refs++
+	c.mu.Unlock()
+
+	l.mu.Lock()
+	return func() {
+		l.mu.Unlock()
+		c.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(c.locks, key)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// remember records a Remediation this process just created for key
+func (c *alertCoalescer) remember(key string, remediation *k8shealerv1alpha1.Remediation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for k, r := range c.recent {
+		if now.Sub(r.created) > c.window {
+			delete(c.recent, k)
+		}
+	}
+	c.recent[key] = recentRemediation{key: client.ObjectKeyFromObject(remediation), created: now}
+}
+
+// find returns the Remediation that alerts for spec's target and action
+// should be folded into, or nil if there is none within the window
+func (c *alertCoalescer) find(ctx context.Context, spec *k8shealerv1alpha1.RemediationSpec) (*client.ObjectKey, error) {
+	key := coalesceKey(spec)
+
+	c.mu.Lock()
+	recent, ok := c.recent[key]
+	c.mu.Unlock()
+	if ok && c.now().Sub(recent.created) <= c.window {
+		// The cache may not have seen our create yet, so NotFound still
+		// counts; once it has, the Remediation must still be coalescible
+		remediation := &k8shealerv1alpha1.Remediation{}
+		err := c.client.Get(ctx, recent.key, remediation)
+		if apierrors.IsNotFound(err) || (err == nil && coalescible(remediation)) {
+			return &recent.key, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Remediation %s: %w", recent.key, err)
+		}
+		c.forget(key, recent.key)
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := c.client.List(ctx, list,
+		client.InNamespace(remediationNamespace(c.central, spec.Target)),
+		client.MatchingLabels{
+			LabelTarget: spec.Target.Name,
+			LabelAction: string(spec.Action.Type),
+		},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list remediations for %s/%s: %w", spec.Target.Namespace, spec.Target.Name, err)
+	}
+
+	var oldest *k8shealerv1alpha1.Remediation
+	for i := range list.Items {
+		item := &list.Items[i]
+		if coalesceKey(&item.Spec) != key || c.now().Sub(item.CreationTimestamp.Time) > c.window || !coalescible(item) {
+			continue
+		}
+		if oldest == nil || item.CreationTimestamp.Before(&oldest.CreationTimestamp) {
+			oldest = item
+		}
+	}
+	if oldest == nil {
+		return nil, nil
+	}
+
+	objectKey := client.ObjectKeyFromObject(oldest)
+	return &objectKey, nil
+}
+
+// forget drops the record of a Remediation this process created for key
+func (c *alertCoalescer) forget(key string, remediation client.ObjectKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.recent[key]; ok && r.key == remediation {
+		delete(c.recent, key)
+	}
+}
+
+// coalescible reports whether alerts may still be folded into remediation:
+// not throttled and not failed, cancelled or expired
+func coalescible(remediation *k8shealerv1alpha1.Remediation) bool {
+	if remediate.IsThrottled(remediation) {
+		return false
+	}
+	switch remediation.Status.Phase {
+	case k8shealerv1alpha1.RemediationPhaseFailed,
+		k8shealerv1alpha1.RemediationPhaseCancelled,
+		k8shealerv1alpha1.RemediationPhaseExpired:
+		return false
+	}
+	return true
+}
+
+// attach records the alert with fingerprint and startsAt on the Remediation
+// at key, so that repeats of it are recognized as duplicates and its resolve
+// notification is matched. An alert that fires again after it resolved
+// counts as unresolved again. The object may not be in the cache yet if it
+// was created moments ago, so NotFound is retried.
+func (c *alertCoalescer) attach(ctx context.Context, key client.ObjectKey, fingerprint string, startsAt time.Time) error {
+	return retry.OnError(c.backoff, func(err error) bool {
+		return apierrors.IsNotFound(err) || apierrors.IsConflict(err) || isTransientError(err)
+	}, func() error {
+		remediation := &k8shealerv1alpha1.Remediation{}
+		if err := c.client.Get(ctx, key, remediation); err != nil {
+			return err
+		}
+
+		if remediation.Spec.Alert.Fingerprint != fingerprint {
+			label, value := coalescedLabel(fingerprint), startsAtLabelValue(startsAt)
+			if remediation.Labels[label] != value {
+				if remediation.Labels == nil {
+					remediation.Labels = map[string]string{}
+				}
+				remediation.Labels[label] = value
+				if err := c.client.Update(ctx, remediation); err != nil {
+					return err
+				}
+			}
+		}
+
+		changed := false
+		if remediation.Spec.Alert.Fingerprint != fingerprint && !slices.Contains(remediation.Status.CoalescedFingerprints, fingerprint) {
+			remediation.Status.CoalescedFingerprints = append(remediation.Status.CoalescedFingerprints, fingerprint)
+			changed = true
+		}
+		if i := slices.Index(remediation.Status.ResolvedFingerprints, fingerprint); i >= 0 {
+			remediation.Status.ResolvedFingerprints = slices.Delete(remediation.Status.ResolvedFingerprints, i, i+1)
+			changed = true
+		}
+		if !changed {
+			return nil
+		}
+		return c.client.Status().Update(ctx, remediation)
+	})
+}
@@ -0,0 +1,363 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestProcessAlert_CoalescesPodAlerts(t *testing.T) {
+	tests := []struct {
+		name               string
+		coalesceWindow     time.Duration
+		expectRemediations int
+		expectCoalesced    int
+	}{
+		{
+			name:               "coalescing enabled",
+			expectRemediations: 1,
+			expectCoalesced:    4,
+		},
+		{
+			name:               "coalescing disabled",
+			coalesceWindow:     -1,
+			expectRemediations: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = k8shealerv1alpha1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			isController := true
+			objects := []client.Object{
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name: "api-5f7b8c9d", Namespace: "prod", UID: "rs-uid",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: "deploy-uid", Controller: &isController,
+					}},
+				}},
+			}
+			for i := 0; i < 5; i++ {
+				objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("api-5f7b8c9d-%d", i), Namespace: "prod",
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5f7b8c9d", UID: "rs-uid", Controller: &isController,
+					}},
+				}})
+			}
+			cl := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objects...).
+				WithStatusSubresource(&k8shealerv1alpha1.Remediation{}).
+				Build()
+			handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{CoalesceWindow: tt.coalesceWindow})
+
+			// One alert per pod, all firing in the same second
+			startsAt := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+			var wg sync.WaitGroup
+			errs := make([]error, 5)
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					errs[i] = handler.processAlert(context.Background(), remediate.SourceAlertmanager, AlertPayload{
+						Status:      "firing",
+						Fingerprint: fmt.Sprintf("fp-%d", i),
+						StartsAt:    startsAt,
+						Labels: map[string]string{
+							"alertname": "KubePodOOMKilled",
+							"namespace": "prod",
+							"pod":       fmt.Sprintf("api-5f7b8c9d-%d", i),
+							"container": "app",
+						},
+					})
+				}()
+			}
+			wg.Wait()
+			for _, err := range errs {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			list := &k8shealerv1alpha1.RemediationList{}
+			if err := cl.List(context.Background(), list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Items) != tt.expectRemediations {
+				t.Fatalf("expected %d remediations, got %d", tt.expectRemediations, len(list.Items))
+			}
+			coalesced := 0
+			for _, item := range list.Items {
+				if item.Spec.Target.Kind != "Deployment" || item.Spec.Target.Name != "api" {
+					t.Errorf("expected target resolved to Deployment/api, got %s/%s", item.Spec.Target.Kind, item.Spec.Target.Name)
+				}
+				coalesced += len(item.Status.CoalescedFingerprints)
+			}
+			if coalesced != tt.expectCoalesced {
+				t.Errorf("expected %d coalesced fingerprints, got %d", tt.expectCoalesced, coalesced)
+			}
+		})
+	}
+}
+
+func TestAlertCoalescer_Find(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	newRemediation := func(name string, age time.Duration, phase k8shealerv1alpha1.RemediationPhase) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "prod",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+				Labels:            map[string]string{LabelTarget: "api", LabelAction: "IncreaseMemory"},
+			},
+			Spec: k8shealerv1alpha1.RemediationSpec{
+				Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "prod"},
+				Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+			},
+			Status: k8shealerv1alpha1.RemediationStatus{Phase: phase},
+		}
+	}
+
+	spec := &k8shealerv1alpha1.RemediationSpec{
+		Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "prod"},
+		Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+	}
+
+	tests := []struct {
+		name        string
+		existing    []client.Object
+		expectMatch string
+	}{
+		{
+			name: "no remediations",
+		},
+		{
+			name:        "recent remediation",
+			existing:    []client.Object{newRemediation("rem-a", time.Minute, k8shealerv1alpha1.RemediationPhaseSucceeded)},
+			expectMatch: "rem-a",
+		},
+		{
+			name:     "outside the window",
+			existing: []client.Object{newRemediation("rem-a", 10*time.Minute, k8shealerv1alpha1.RemediationPhasePending)},
+		},
+		{
+			name:     "failed remediation",
+			existing: []client.Object{newRemediation("rem-a", time.Minute, k8shealerv1alpha1.RemediationPhaseFailed)},
+		},
+		{
+			name: "oldest wins",
+			existing: []client.Object{
+				newRemediation("rem-new", time.Minute, k8shealerv1alpha1.RemediationPhasePending),
+				newRemediation("rem-old", 3*time.Minute, k8shealerv1alpha1.RemediationPhasePending),
+			},
+			expectMatch: "rem-old",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.existing...).Build()
+			coalescer := newAlertCoalescer(cl, DefaultCoalesceWindow, "", DefaultRetryBackoff)
+			coalescer.now = func() time.Time { return now }
+
+			key, err := coalescer.find(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectMatch == "" {
+				if key != nil {
+					t.Errorf("expected no match, got %s", key)
+				}
+				return
+			}
+			if key == nil || *key != (types.NamespacedName{Namespace: "prod", Name: tt.expectMatch}) {
+				t.Errorf("expected %s, got %v", tt.expectMatch, key)
+			}
+		})
+	}
+}
+
+func TestProcessAlert_CoalescedAlertResendAndResolve(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	isController := true
+	objects := []client.Object{
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Name: "api-5f7b8c9d", Namespace: "prod", UID: "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "Deployment", Name: "api", UID: "deploy-uid", Controller: &isController,
+			}},
+		}},
+	}
+	for i := 0; i < 2; i++ {
+		objects = append(objects, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name: fmt.Sprintf("api-5f7b8c9d-%d", i), Namespace: "prod",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "api-5f7b8c9d", UID: "rs-uid", Controller: &isController,
+			}},
+		}})
+	}
+	cl := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&k8shealerv1alpha1.Remediation{}).
+		Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{})
+	ctx := context.Background()
+
+	startsAt := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	alert := func(i int) AlertPayload {
+		return AlertPayload{
+			Status:      "firing",
+			Fingerprint: fmt.Sprintf("fp-%d", i),
+			StartsAt:    startsAt,
+			Labels: map[string]string{
+				"alertname": "KubePodOOMKilled",
+				"namespace": "prod",
+				"pod":       fmt.Sprintf("api-5f7b8c9d-%d", i),
+				"container": "app",
+			},
+		}
+	}
+	remediations := func() []k8shealerv1alpha1.Remediation {
+		list := &k8shealerv1alpha1.RemediationList{}
+		if err := cl.List(ctx, list); err != nil {
+			t.Fatal(err)
+		}
+		return list.Items
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := handler.processAlert(ctx, remediate.SourceAlertmanager, alert(i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	items := remediations()
+	if len(items) != 1 {
+		t.Fatalf("expected 1 remediation, got %d", len(items))
+	}
+	if got := items[0].Labels[LabelCoalescedPrefix+"fp-1"]; got != startsAtLabelValue(startsAt) {
+		t.Errorf("expected the coalesced alert's startsAt in its label, got %q", got)
+	}
+
+	// Alertmanager re-sends the coalesced alert after the coalesce window
+	// has closed; it must not create a second Remediation
+	rem := &items[0]
+	rem.CreationTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	if err := cl.Update(ctx, rem); err != nil {
+		t.Fatal(err)
+	}
+	handler.coalescer.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if err := handler.processAlert(ctx, remediate.SourceAlertmanager, alert(1)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if items := remediations(); len(items) != 1 {
+		t.Fatalf("expected the resent alert to be a duplicate, got %d remediations", len(items))
+	}
+
+	// The first alert resolving leaves the Remediation alone while the other pod still alerts
+	resolve := func(i int) {
+		if err := handler.processResolvedAlert(ctx, AlertPayload{Status: "resolved", Fingerprint: fmt.Sprintf("fp-%d", i), EndsAt: time.Now()}); err != nil {
+			t.Fatalf("processResolvedAlert failed: %v", err)
+		}
+	}
+	resolve(0)
+	items = remediations()
+	if items[0].Status.Phase == k8shealerv1alpha1.RemediationPhaseCancelled {
+		t.Fatalf("expected the remediation to stay open while fp-1 is firing")
+	}
+	if len(items[0].Status.ResolvedFingerprints) != 1 || items[0].Status.ResolvedFingerprints[0] != "fp-0" {
+		t.Errorf("expected fp-0 recorded as resolved, got %v", items[0].Status.ResolvedFingerprints)
+	}
+
+	resolve(1)
+	if items := remediations(); items[0].Status.Phase != k8shealerv1alpha1.RemediationPhaseCancelled {
+		t.Errorf("expected Cancelled once every alert resolved, got %s", items[0].Status.Phase)
+	}
+}
+
+func TestAlertCoalescer_FindRecentChecksPhase(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	spec := &k8shealerv1alpha1.RemediationSpec{
+		Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "prod"},
+		Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+	}
+	remediation := func(name string, phase k8shealerv1alpha1.RemediationPhase) *k8shealerv1alpha1.Remediation {
+		return &k8shealerv1alpha1.Remediation{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "prod"},
+			Spec:       *spec,
+			Status:     k8shealerv1alpha1.RemediationStatus{Phase: phase},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		existing    []client.Object
+		expectMatch bool
+	}{
+		{
+			name:        "not in the cache yet",
+			expectMatch: true,
+		},
+		{
+			name:        "pending",
+			existing:    []client.Object{remediation("rem-a", k8shealerv1alpha1.RemediationPhasePending)},
+			expectMatch: true,
+		},
+		{
+			name:     "failed",
+			existing: []client.Object{remediation("rem-a", k8shealerv1alpha1.RemediationPhaseFailed)},
+		},
+		{
+			name:     "cancelled",
+			existing: []client.Object{remediation("rem-a", k8shealerv1alpha1.RemediationPhaseCancelled)},
+		},
+		{
+			name:     "expired",
+			existing: []client.Object{remediation("rem-a", k8shealerv1alpha1.RemediationPhaseExpired)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.existing...).Build()
+			coalescer := newAlertCoalescer(cl, DefaultCoalesceWindow, "", DefaultRetryBackoff)
+			coalescer.remember(coalesceKey(spec), remediation("rem-a", ""))
+
+			key, err := coalescer.find(context.Background(), spec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.expectMatch != (key != nil) {
+				t.Errorf("expected match %v, got %v", tt.expectMatch, key)
+			}
+		})
+	}
+}
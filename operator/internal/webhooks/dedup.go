@@ -0,0 +1,136 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const (
+	// LabelFingerprint records the Alertmanager fingerprint of the alert
+	LabelFingerprint = "k8s-healer.io/fingerprint"
+
+	// LabelStartsAt records the alert's startsAt as Unix seconds
+	LabelStartsAt = "k8s-healer.io/starts-at"
+
+	// LabelCoalescedPrefix prefixes one label per alert coalesced into a
+	// Remediation. The name is the alert's fingerprint and the value its
+	// startsAt as Unix seconds, so coalesced alerts are found like the first.
+	LabelCoalescedPrefix = "coalesced.k8s-healer.io/"
+
+	// DefaultDedupWindow is how long a Remediation suppresses repeats of its alert
+	DefaultDedupWindow = 1 * time.Hour
+)
+
+// AlertDeduplicator decides whether an alert was already handled by looking
+// up existing Remediations instead of keeping state in memory, so the
+// decision survives restarts and is shared by all replicas
+type AlertDeduplicator struct {
+	client client.Reader
+	window time.Duration
+}
+
+// NewAlertDeduplicator creates a deduplicator reading Remediations from
+// reader, normally the manager's cached client
+func NewAlertDeduplicator(reader client.Reader, window time.Duration) *AlertDeduplicator {
+	return &AlertDeduplicator{
+		client: reader,
+		window: window,
+	}
+}
+
+// IsDuplicate reports whether a Remediation for the same alert instance
+// (fingerprint and startsAt) was created within the dedup window, either for
+// the alert itself or for an alert it was coalesced with. Throttled
+// Remediations do not count, so the alert is checked against the rate
+// limits again.
+func (d *AlertDeduplicator) IsDuplicate(ctx context.Context, fingerprint string, startsAt time.Time) (bool, error) {
+	if fingerprint == "" {
+		return false, nil
+	}
+
+	remediations, err := remediationsForAlert(ctx, d.client, fingerprint, &startsAt)
+	if err != nil {
+		return false, err
+	}
+
+	cutoff := time.Now().Add(-d.window)
+	for i := range remediations {
+		if remediate.IsThrottled(&remediations[i]) {
+			continue
+		}
+		if remediations[i].CreationTimestamp.Time.After(cutoff) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// remediationsForAlert returns the Remediations the alert with fingerprint
+// contributed to, as the alert that created them or a coalesced one. If
+// startsAt is set only that instance of the alert matches.
+func remediationsForAlert(ctx context.Context, reader client.Reader, fingerprint string, startsAt *time.Time) ([]k8shealerv1alpha1.Remediation, error) {
+	created := client.MatchingLabels{LabelFingerprint: fingerprint}
+	var coalesced client.ListOption = client.HasLabels{coalescedLabel(fingerprint)}
+	if startsAt != nil {
+		created[LabelStartsAt] = startsAtLabelValue(*startsAt)
+		coalesced = client.MatchingLabels{coalescedLabel(fingerprint): startsAtLabelValue(*startsAt)}
+	}
+
+	var remediations []k8shealerv1alpha1.Remediation
+	seen := map[client.ObjectKey]bool{}
+	for _, selector := range []client.ListOption{created, coalesced} {
+		list := &k8shealerv1alpha1.RemediationList{}
+		if err := reader.List(ctx, list, selector); err != nil {
+			return nil, fmt.Errorf("failed to list remediations for fingerprint %s: %w", fingerprint, err)
+		}
+		for _, item := range list.Items {
+			if key := client.ObjectKeyFromObject(&item); !seen[key] {
+				seen[key] = true
+				remediations = append(remediations, item)
+			}
+		}
+	}
+	return remediations, nil
+}
+
+// coalescedLabel returns the label recording that the alert with
+// fingerprint was coalesced into a Remediation. Fingerprints that are not
+// valid label names are hashed.
+func coalescedLabel(fingerprint string) string {
+	if len(validation.IsQualifiedName(LabelCoalescedPrefix+fingerprint)) > 0 {
+		sum := sha256.Sum256([]byte(fingerprint))
+		fingerprint = hex.EncodeToString(sum[:])[:16]
+	}
+	return LabelCoalescedPrefix + fingerprint
+}
+
+func startsAtLabelValue(startsAt time.Time) string {
+	return strconv.FormatInt(startsAt.Unix(), 10)
+}
@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestAlertDeduplicator_IsDuplicate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	startsAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	recent := newRemediation("recent", "fp-recent", k8shealerv1alpha1.RemediationPhasePending)
+	recent.Labels[LabelStartsAt] = startsAtLabelValue(startsAt)
+	recent.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+
+	old := newRemediation("old", "fp-old", k8shealerv1alpha1.RemediationPhaseSucceeded)
+	old.Labels[LabelStartsAt] = startsAtLabelValue(startsAt)
+	old.CreationTimestamp = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	throttled := newRemediation("throttled", "fp-throttled", k8shealerv1alpha1.RemediationPhaseThrottled)
+	throttled.Labels[LabelStartsAt] = startsAtLabelValue(startsAt)
+	throttled.Annotations = map[string]string{remediate.AnnotationThrottled: "cooldown"}
+	throttled.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Minute))
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(recent, old, throttled).Build()
+	dedup := NewAlertDeduplicator(cl, time.Hour)
+
+	tests := []struct {
+		name        string
+		fingerprint string
+		startsAt    time.Time
+		expected    bool
+	}{
+		{
+			name:        "existing remediation within window",
+			fingerprint: "fp-recent",
+			startsAt:    startsAt,
+			expected:    true,
+		},
+		{
+			name:        "existing remediation outside window",
+			fingerprint: "fp-old",
+			startsAt:    startsAt,
+			expected:    false,
+		},
+		{
+			name:        "throttled remediation within window",
+			fingerprint: "fp-throttled",
+			startsAt:    startsAt,
+			expected:    false,
+		},
+		{
+			name:        "same fingerprint, new firing",
+			fingerprint: "fp-recent",
+			startsAt:    startsAt.Add(time.Hour),
+			expected:    false,
+		},
+		{
+			name:        "unknown fingerprint",
+			fingerprint: "fp-new",
+			startsAt:    startsAt,
+			expected:    false,
+		},
+		{
+			name:        "empty fingerprint",
+			fingerprint: "",
+			startsAt:    startsAt,
+			expected:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duplicate, err := dedup.IsDuplicate(context.Background(), tt.fingerprint, tt.startsAt)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if duplicate != tt.expected {
+				t.Errorf("expected duplicate=%v, got %v", tt.expected, duplicate)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// DryRunResponse is returned by the dry-run endpoint, one result per alert
+type DryRunResponse struct {
+	Results []DryRunResult `json:"results"`
+}
+
+// DryRunResult is what would happen to a single alert
+type DryRunResult struct {
+	Alertname   string `json:"alertname"`
+	Fingerprint string `json:"fingerprint"`
+	Status      string `json:"status"`
+	// Skipped is the reason the alert would not create Remediations (not-firing, duplicate)
+	Skipped string `json:"skipped,omitempty"`
+	// Error is set if the alert could not be routed
+	Error        string              `json:"error,omitempty"`
+	Remediations []DryRunRemediation `json:"remediations,omitempty"`
+}
+
+// DryRunRemediation is a Remediation the alert would create
+type DryRunRemediation struct {
+	Route string                            `json:"route"`
+	Name  string                            `json:"name"`
+	Spec  k8shealerv1alpha1.RemediationSpec `json:"spec"`
+	// Target is the workload the remediation would change, after resolving pods to their owner
+	Target *k8shealerv1alpha1.TargetResource `json:"target,omitempty"`
+	// Changes are the fields the action would change on the live object
+	Changes []remediate.Change `json:"changes,omitempty"`
+	// Skipped is the reason no Remediation would be created for this route (opted-out)
+	Skipped string `json:"skipped,omitempty"`
+	// CoalesceInto names the existing Remediation the alert would be folded into instead
+	CoalesceInto string `json:"coalesceInto,omitempty"`
+	// Throttled is the rate limit the Remediation would be throttled by
+	Throttled string `json:"throttled,omitempty"`
+	// Error is set if the target could not be resolved or the action would fail
+	Error string `json:"error,omitempty"`
+}
+
+// HandleDryRun accepts an Alertmanager payload and reports, per alert, the
+// matched route, the Remediation spec, the resolved target and the changes
+// the action would make to the live object. Nothing is created or modified.
+func (h *AlertmanagerHandler) HandleDryRun(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	var payload AlertmanagerWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+
+	response := DryRunResponse{Results: make([]DryRunResult, 0, len(payload.Alerts))}
+	for _, alert := range payload.Alerts {
+		response.Results = append(response.Results, h.dryRunAlert(ctx, alert))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		h.logger.Error(err, "Failed to write dry-run response")
+	}
+}
+
+func (h *AlertmanagerHandler) dryRunAlert(ctx context.Context, alert AlertPayload) DryRunResult {
+	result := DryRunResult{
+		Alertname:   alert.Labels["alertname"],
+		Fingerprint: alert.Fingerprint,
+		Status:      alert.Status,
+	}
+
+	if alert.Status != "firing" {
+		result.Skipped = "not-firing"
+		return result
+	}
+
+	duplicate, err := h.dedup.IsDuplicate(ctx, alert.Fingerprint, alert.StartsAt)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if duplicate {
+		result.Skipped = "duplicate"
+		return result
+	}
+
+	routed, err := remediate.RouteAlert(remediate.Alert{
+		Labels:      alert.Labels,
+		Annotations: alert.Annotations,
+		Status:      alert.Status,
+		StartsAt:    alert.StartsAt.Format(time.RFC3339),
+		Fingerprint: alert.Fingerprint,
+		Source:      remediate.SourceAlertmanager,
+	}, h.routes.RouterConfig(), func(target *k8shealerv1alpha1.TargetResource) {
+		h.resolveTarget(ctx, h.logger, target)
+	})
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	for _, routeResult := range routed {
+		optOut, scopeErr := h.checkScope(ctx, h.logger, routeResult.Spec)
+		remediation := buildRemediation(alert, remediationName(alert, routeResult.Rule, routeResult.Spec.Target),
+			remediationNamespace(h.remediationNamespace, routeResult.Spec.Target), routeResult)
+		preview := DryRunRemediation{
+			Route: routeResult.Rule,
+			Name:  remediation.Name,
+			Spec:  remediation.Spec,
+		}
+		if scopeErr != nil {
+			preview.Error = scopeErr.Error()
+			result.Remediations = append(result.Remediations, preview)
+			continue
+		}
+		if optOut != "" {
+			preview.Skipped = "opted-out: " + optOut
+			result.Remediations = append(result.Remediations, preview)
+			continue
+		}
+
+		if h.coalescer.enabled() {
+			existing, err := h.coalescer.find(ctx, &remediation.Spec)
+			if err != nil {
+				preview.Error = err.Error()
+				result.Remediations = append(result.Remediations, preview)
+				continue
+			}
+			if existing != nil && existing.Name != remediation.Name {
+				preview.CoalesceInto = existing.String()
+			}
+		}
+		if preview.CoalesceInto == "" {
+			reason, err := h.rateLimiter.preview(ctx, &remediation.Spec)
+			if err != nil {
+				preview.Error = err.Error()
+				result.Remediations = append(result.Remediations, preview)
+				continue
+			}
+			preview.Throttled = reason
+		}
+
+		target, changes, err := h.previewAction(ctx, remediation.Spec)
+		preview.Target = target
+		preview.Changes = changes
+		if err != nil {
+			preview.Error = err.Error()
+		}
+
+		result.Remediations = append(result.Remediations, preview)
+	}
+
+	return result
+}
+
+// previewAction resolves the target and applies the action to a copy of the live object
+func (h *AlertmanagerHandler) previewAction(ctx context.Context, spec k8shealerv1alpha1.RemediationSpec) (*k8shealerv1alpha1.TargetResource, []remediate.Change, error) {
+	target := spec.Target
+	if target.Kind == "Pod" {
+		kind, name, err := remediate.ResolvePodOwner(ctx, h.client, target.Namespace, target.Name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot resolve target: %w", err)
+		}
+		target.Kind = kind
+		target.Name = name
+	}
+
+	live, err := remediate.NewTargetObject(target.Kind)
+	if err != nil {
+		return &target, nil, err
+	}
+	if err := h.client.Get(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, live); err != nil {
+		return &target, nil, fmt.Errorf("failed to get target %s %s/%s: %w", target.Kind, target.Namespace, target.Name, err)
+	}
+
+	after := live.DeepCopyObject().(client.Object)
+	if err := remediate.ApplyAction(ctx, h.client, after, target, spec.Action); err != nil {
+		return &target, nil, err
+	}
+
+	changes, err := remediate.DiffObjects(live, after)
+	return &target, changes, err
+}
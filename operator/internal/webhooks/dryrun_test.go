@@ -0,0 +1,123 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const dryRunPayload = `{"alerts": [
+  {"status": "firing", "fingerprint": "fp1", "startsAt": "2026-01-02T15:04:05Z",
+   "labels": {"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api", "container": "app", "severity": "critical"}},
+  {"status": "firing", "fingerprint": "fp2", "startsAt": "2026-01-02T15:04:05Z",
+   "labels": {"alertname": "SomethingElse", "namespace": "prod", "deployment": "api"}},
+  {"status": "resolved", "fingerprint": "fp3", "startsAt": "2026-01-02T15:04:05Z",
+   "labels": {"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"}}
+]}`
+
+func TestHandleDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:  "app",
+						Image: "api:1.0",
+						Resources: corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+						},
+					}},
+				},
+			},
+		},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(deployment).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/dry-run", strings.NewReader(dryRunPayload))
+	rec := httptest.NewRecorder()
+	handler.HandleDryRun(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var response DryRunResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(response.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(response.Results))
+	}
+
+	oom := response.Results[0]
+	if len(oom.Remediations) != 1 {
+		t.Fatalf("expected 1 remediation, got %+v", oom)
+	}
+	preview := oom.Remediations[0]
+	if preview.Route != "KubePodOOMKilled" || preview.Spec.Action.Type != k8shealerv1alpha1.ActionTypeIncreaseMemory {
+		t.Errorf("unexpected route %s / action %s", preview.Route, preview.Spec.Action.Type)
+	}
+	if preview.Target == nil || preview.Target.Kind != "Deployment" || preview.Target.Name != "api" {
+		t.Errorf("unexpected target %+v", preview.Target)
+	}
+	if preview.Error != "" {
+		t.Errorf("unexpected error: %s", preview.Error)
+	}
+	found := false
+	for _, change := range preview.Changes {
+		if change.Path == "spec.template.spec.containers[0].resources.limits.memory" {
+			found = true
+			if change.Before != "256Mi" || change.After != "320Mi" {
+				t.Errorf("expected 256Mi -> 320Mi, got %s -> %s", change.Before, change.After)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("expected memory limit change, got %+v", preview.Changes)
+	}
+
+	if response.Results[1].Error == "" {
+		t.Error("expected routing error for unknown alert")
+	}
+	if response.Results[2].Skipped != "not-firing" {
+		t.Errorf("expected resolved alert to be skipped, got %+v", response.Results[2])
+	}
+
+	// Nothing is created or changed
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 0 {
+		t.Errorf("expected no remediations, got %d", len(list.Items))
+	}
+	live := &appsv1.Deployment{}
+	if err := cl.Get(context.Background(), client.ObjectKeyFromObject(deployment), live); err != nil {
+		t.Fatal(err)
+	}
+	limit := live.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]
+	if limit.String() != "256Mi" {
+		t.Errorf("expected live deployment unchanged, got %s", limit.String())
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+const (
+	// remediationNamePrefix starts every generated Remediation name
+	remediationNamePrefix = "rem"
+
+	// maxRemediationNameLength keeps generated names usable as label values
+	maxRemediationNameLength = 63
+
+	// remediationNameHashLength is the number of hex characters of the hash suffix
+	remediationNameHashLength = 10
+)
+
+// remediationName returns a DNS-1123 name for the Remediation created for an
+// alert instance and route. The readable part is derived from the alertname;
+// uniqueness comes from a hash of the fingerprint, start time, target and
+// rule, so the same alert instance always maps to the same name while
+// different workloads alerting in the same second don't collide.
+func remediationName(alert AlertPayload, rule string, target k8shealerv1alpha1.TargetResource) string {
+	hash := sha256.New()
+	for _, part := range []string{
+		alert.Fingerprint,
+		strconv.FormatInt(alert.StartsAt.Unix(), 10),
+		target.Kind,
+		target.Namespace,
+		target.Name,
+		target.Container,
+		rule,
+	} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	suffix := hex.EncodeToString(hash.Sum(nil))[:remediationNameHashLength]
+
+	prefix := remediationNamePrefix
+	if alertname := sanitizeDNSLabel(alert.Labels["alertname"]); alertname != "" {
+		prefix = prefix + "-" + alertname
+	}
+	if max := maxRemediationNameLength - len(suffix) - 1; len(prefix) > max {
+		prefix = strings.TrimRight(prefix[:max], "-")
+	}
+
+	return prefix + "-" + suffix
+}
+
+// throttledName returns the name of the record kept when the Remediation
+// named name is throttled at the given time. It differs from name so the
+// Remediation itself can still be created once the limits allow it.
+func throttledName(name string, at time.Time) string {
+	suffix := "-throttled-" + strconv.FormatInt(at.Unix(), 36)
+	// Keep the hash at the end of name and shorten the readable part instead
+	head, hash := name, ""
+	if i := strings.LastIndex(name, "-"); i >= 0 {
+		head, hash = name[:i], name[i:]
+	}
+	if max := maxRemediationNameLength - len(hash) - len(suffix); len(head) > max {
+		head = strings.TrimRight(head[:max], "-")
+	}
+	return head + hash + suffix
+}
+
+// sanitizeDNSLabel lowercases s and replaces runs of characters not allowed
+// in a DNS-1123 label with a single dash. CamelCase words are split so that
+// "KubePodOOMKilled" becomes "kube-pod-oom-killed".
+func sanitizeDNSLabel(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	dash := false
+	for i, r := range runes {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			// Start a new word at a lower-to-upper boundary or at the last
+			// capital of an acronym ("OOMKilled" -> "oom-killed")
+			if i > 0 && b.Len() > 0 && !dash {
+				prev := runes[i-1]
+				nextLower := i+1 < len(runes) && runes[i+1] >= 'a' && runes[i+1] <= 'z'
+				if (prev >= 'a' && prev <= 'z') || (prev >= '0' && prev <= '9') || (prev >= 'A' && prev <= 'Z' && nextLower) {
+					b.WriteByte('-')
+				}
+			}
+			b.WriteRune(r + ('a' - 'A'))
+			dash = false
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+			dash = false
+		default:
+			if b.Len() > 0 && !dash {
+				b.WriteByte('-')
+				dash = true
+			}
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+// labelValue returns s if it is a valid label value. Otherwise runs of
+// disallowed characters become a dash and a value that is still too long is
+// cut and given a hash of s, so "High memory usage" becomes
+// "High-memory-usage".
+func labelValue(s string) string {
+	if len(validation.IsValidLabelValue(s)) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' || r == '.' {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	value := strings.Trim(b.String(), "-_.")
+
+	if len(value) > validation.LabelValueMaxLength {
+		sum := sha256.Sum256([]byte(s))
+		suffix := hex.EncodeToString(sum[:])[:remediationNameHashLength]
+		value = strings.TrimRight(value[:validation.LabelValueMaxLength-len(suffix)-1], "-_.") + "-" + suffix
+	}
+	return value
+}
@@ -0,0 +1,165 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestSanitizeDNSLabel(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"KubePodOOMKilled", "kube-pod-oom-killed"},
+		{"KubeHpaMaxedOut", "kube-hpa-maxed-out"},
+		{"CPUThrottlingHigh", "cpu-throttling-high"},
+		{"node_disk_full", "node-disk-full"},
+		{"__Weird..Name__", "weird-name"},
+		{"already-valid-1", "already-valid-1"},
+		{"日本", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := sanitizeDNSLabel(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLabelValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"KubePodOOMKilled", "KubePodOOMKilled"},
+		{"High memory usage", "High-memory-usage"},
+		{"  CPU > 90% (5m)  ", "CPU-90-5m"},
+		{"node_disk.full", "node_disk.full"},
+		{"日本", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := labelValue(tt.input); got != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	long := strings.Repeat("Memory usage very high ", 5)
+	got := labelValue(long)
+	if errs := validation.IsValidLabelValue(got); len(errs) > 0 {
+		t.Errorf("expected a valid label value for a long title, got %q: %v", got, errs)
+	}
+	if other := labelValue(long + "again"); other == got {
+		t.Errorf("expected long titles differing past the cut to stay distinct, both got %q", got)
+	}
+}
+
+func TestRemediationName(t *testing.T) {
+	startsAt := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+	alert := AlertPayload{
+		Fingerprint: "abc123",
+		StartsAt:    startsAt,
+		Labels:      map[string]string{"alertname": "KubePodOOMKilled"},
+	}
+	api := k8shealerv1alpha1.TargetResource{Kind: "Deployment", Namespace: "prod", Name: "api"}
+	worker := k8shealerv1alpha1.TargetResource{Kind: "Deployment", Namespace: "prod", Name: "worker"}
+
+	name := remediationName(alert, "oom", api)
+	if !strings.HasPrefix(name, "rem-kube-pod-oom-killed-") {
+		t.Errorf("unexpected name %q", name)
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		t.Errorf("name %q is not a DNS-1123 label: %v", name, errs)
+	}
+
+	// Stable for the same alert instance
+	if again := remediationName(alert, "oom", api); again != name {
+		t.Errorf("expected stable name, got %q and %q", name, again)
+	}
+
+	// Distinct for other targets, rules and alert instances in the same second
+	other := alert
+	other.Fingerprint = "def456"
+	for _, candidate := range []string{
+		remediationName(alert, "oom", worker),
+		remediationName(alert, "oom-notify", api),
+		remediationName(other, "oom", api),
+	} {
+		if candidate == name {
+			t.Errorf("expected distinct name, got %q twice", name)
+		}
+	}
+
+	// Long and empty alertnames still produce valid names, throttled or not
+	long := alert
+	long.Labels = map[string]string{"alertname": strings.Repeat("VeryLongAlertName", 10)}
+	for _, a := range []AlertPayload{long, {StartsAt: startsAt}} {
+		n := remediationName(a, "oom", api)
+		for _, candidate := range []string{n, throttledName(n, startsAt)} {
+			if errs := validation.IsDNS1123Label(candidate); len(errs) > 0 {
+				t.Errorf("name %q is not a DNS-1123 label: %v", candidate, errs)
+			}
+		}
+	}
+
+	// Throttled records keep the hash and differ from the Remediation
+	throttled := throttledName(name, startsAt)
+	if throttled == name || !strings.Contains(throttled, name[strings.LastIndex(name, "-"):]+"-throttled-") {
+		t.Errorf("unexpected throttled name %q for %q", throttled, name)
+	}
+}
+
+func TestProcessAlert_RemediationNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{RemediationNamespace: "heal8s-system"})
+
+	for _, namespace := range []string{"team-a", "team-b"} {
+		err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, AlertPayload{
+			Status:      "firing",
+			Fingerprint: "fp-" + namespace,
+			StartsAt:    time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+			Labels:      map[string]string{"alertname": "KubePodOOMKilled", "namespace": namespace, "deployment": "api"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list, client.InNamespace("heal8s-system")); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 remediations in the central namespace, got %d", len(list.Items))
+	}
+
+	// Per-team filtering by label
+	if err := cl.List(context.Background(), list, client.MatchingLabels{LabelTargetNamespace: "team-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 || list.Items[0].Spec.Target.Namespace != "team-a" || list.Items[0].Namespace != "heal8s-system" {
+		t.Errorf("unexpected remediations for team-a: %+v", list.Items)
+	}
+}
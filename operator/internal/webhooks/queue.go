@@ -0,0 +1,185 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+)
+
+const (
+	// DefaultQueueSize is the number of alerts that can wait for a worker
+	DefaultQueueSize = 1000
+
+	// DefaultWorkers is the number of alerts processed concurrently
+	DefaultWorkers = 4
+
+	// DefaultDrainTimeout bounds how long shutdown waits for queued alerts
+	DefaultDrainTimeout = 20 * time.Second
+)
+
+var (
+	// ErrQueueFull is returned when an alert cannot be queued because the queue is at capacity
+	ErrQueueFull = errors.New("alert queue is full")
+
+	// ErrQueueClosed is returned when an alert is queued after shutdown started
+	ErrQueueClosed = errors.New("alert queue is shutting down")
+
+	// DefaultRetryBackoff is used to retry transient API server errors while processing an alert
+	DefaultRetryBackoff = wait.Backoff{
+		Steps:    5,
+		Duration: 200 * time.Millisecond,
+		Factor:   2.0,
+		Jitter:   0.1,
+	}
+)
+
+// alertTask is a unit of work on the alert queue
+type alertTask struct {
+	// status is the alert status (firing, resolved) used as a metric label
+	status    string
+	alertname string
+	enqueued  time.Time
+	process   func(ctx context.Context) error
+}
+
+// alertQueue is a bounded queue of alerts processed by a fixed pool of workers.
+// It is started by the manager and drains queued alerts on shutdown.
+type alertQueue struct {
+	logger       logr.Logger
+	items        chan alertTask
+	workers      int
+	drainTimeout time.Duration
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func newAlertQueue(logger logr.Logger, size, workers int, drainTimeout time.Duration) *alertQueue {
+	return &alertQueue{
+		logger:       logger,
+		items:        make(chan alertTask, size),
+		workers:      workers,
+		drainTimeout: drainTimeout,
+	}
+}
+
+// enqueue adds a task without blocking. It returns ErrQueueFull if the queue
+// is at capacity and ErrQueueClosed once shutdown started.
+func (q *alertQueue) enqueue(task alertTask) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.closed {
+		return ErrQueueClosed
+	}
+
+	task.enqueued = time.Now()
+	select {
+	case q.items <- task:
+		metrics.AlertQueueDepth.Inc()
+		return nil
+	default:
+		return ErrQueueFull
+	}
+}
+
+// start runs the workers until ctx is cancelled, then stops accepting alerts
+// and waits up to the drain timeout for queued alerts to be processed
+func (q *alertQueue) start(ctx context.Context) error {
+	// Workers get their own context so that alerts queued before shutdown can
+	// still reach the API server while draining
+	workCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range q.items {
+				q.process(workCtx, task)
+			}
+		}()
+	}
+
+	<-ctx.Done()
+
+	q.mu.Lock()
+	q.closed = true
+	close(q.items)
+	q.mu.Unlock()
+
+	q.logger.Info("Draining alert queue", "queued", len(q.items))
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.logger.Info("Alert queue drained")
+	case <-time.After(q.drainTimeout):
+		q.logger.Info("Timed out draining alert queue, dropping remaining alerts", "remaining", len(q.items))
+		cancel()
+		<-done
+	}
+
+	return nil
+}
+
+func (q *alertQueue) process(ctx context.Context, task alertTask) {
+	metrics.AlertQueueDepth.Dec()
+	start := time.Now()
+	metrics.AlertQueueWait.Observe(start.Sub(task.enqueued).Seconds())
+
+	result := "success"
+	if err := task.process(ctx); err != nil {
+		result = "error"
+		q.logger.Error(err, "Failed to process alert",
+			"alertname", task.alertname,
+			"status", task.status)
+	}
+
+	metrics.AlertProcessingDuration.WithLabelValues(task.status, result).Observe(time.Since(start).Seconds())
+}
+
+// isTransientError reports whether err is worth retrying: API server
+// throttling, timeouts, unavailability and network errors
+func isTransientError(err error) bool {
+	if apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServerTimeout(err) ||
+		apierrors.IsTimeout(err) ||
+		apierrors.IsServiceUnavailable(err) ||
+		apierrors.IsInternalError(err) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
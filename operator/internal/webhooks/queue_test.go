@@ -0,0 +1,160 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const firingPayload = `{"status":"firing","alerts":[
+  {"status":"firing","labels":{"alertname":"KubePodOOMKilled"},"fingerprint":"a"},
+  {"status":"firing","labels":{"alertname":"KubePodOOMKilled"},"fingerprint":"b"}
+]}`
+
+func TestHandleWebhook_QueueFull(t *testing.T) {
+	// Workers are never started, so the queue only fills up
+	handler := NewAlertmanagerHandler(nil, nil, logr.Discard(), HandlerOptions{QueueSize: 1})
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/alertmanager", strings.NewReader(firingPayload))
+	rec := httptest.NewRecorder()
+	handler.HandleWebhook(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
+
+func TestAlertQueue_DrainsOnShutdown(t *testing.T) {
+	queue := newAlertQueue(logr.Discard(), 10, 1, time.Minute)
+
+	var processed atomic.Int32
+	for i := 0; i < 5; i++ {
+		err := queue.enqueue(alertTask{
+			status: "firing",
+			process: func(ctx context.Context) error {
+				processed.Add(1)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("unexpected enqueue error: %v", err)
+		}
+	}
+
+	// Cancel before the workers start: everything queued must still be processed
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := queue.start(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := processed.Load(); got != 5 {
+		t.Errorf("expected 5 alerts processed, got %d", got)
+	}
+	if err := queue.enqueue(alertTask{}); err != ErrQueueClosed {
+		t.Errorf("expected ErrQueueClosed after shutdown, got %v", err)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	gr := schema.GroupResource{Group: "k8shealer.k8s-healer.io", Resource: "remediations"}
+
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{"too many requests", apierrors.NewTooManyRequests("slow down", 1), true},
+		{"server timeout", apierrors.NewServerTimeout(gr, "create", 1), true},
+		{"service unavailable", apierrors.NewServiceUnavailable("unavailable"), true},
+		{"wrapped internal error", fmt.Errorf("failed: %w", apierrors.NewInternalError(fmt.Errorf("boom"))), true},
+		{"forbidden", apierrors.NewForbidden(gr, "rem", fmt.Errorf("denied")), false},
+		{"invalid", apierrors.NewBadRequest("bad"), false},
+		{"plain error", fmt.Errorf("no route matched alert"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientError(tt.err); got != tt.transient {
+				t.Errorf("expected transient=%v, got %v", tt.transient, got)
+			}
+		})
+	}
+}
+
+func TestProcessAlert_RetriesTransientDedupErrors(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	// The first lookup of existing Remediations fails
+	var failures atomic.Int32
+	failures.Store(1)
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*k8shealerv1alpha1.RemediationList); ok && failures.Add(-1) >= 0 {
+				return apierrors.NewServiceUnavailable("etcd is busy")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{
+		RetryBackoff: &wait.Backoff{Steps: 3, Duration: time.Millisecond},
+	})
+
+	alert := AlertPayload{
+		Status:      "firing",
+		Fingerprint: "fp-retry",
+		StartsAt:    time.Now(),
+		Labels:      map[string]string{"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"},
+	}
+	if err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 {
+		t.Errorf("expected 1 remediation after retrying, got %d", len(list.Items))
+	}
+}
+
+func TestProcessAlert_NoRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{})
+
+	alert := AlertPayload{
+		Status:      "firing",
+		Fingerprint: "fp-unrouted",
+		StartsAt:    time.Now(),
+		Labels:      map[string]string{"alertname": "SomethingElse", "namespace": "prod", "deployment": "api"},
+	}
+	if err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, alert); err != nil {
+		t.Errorf("expected an alert without a route to be skipped, got %v", err)
+	}
+}
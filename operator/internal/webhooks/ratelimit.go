@@ -0,0 +1,162 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// RateLimitSource provides the rate limits currently in effect
+type RateLimitSource interface {
+	RateLimits() remediate.RateLimits
+}
+
+// StaticRateLimits is a RateLimitSource that always returns the same limits
+type StaticRateLimits remediate.RateLimits
+
+// RateLimits returns the wrapped limits
+func (l StaticRateLimits) RateLimits() remediate.RateLimits {
+	return remediate.RateLimits(l)
+}
+
+// rateLimiter applies remediate.RateLimits to new Remediations. Budgets are
+// counted from the Remediations in the cluster; creates by this process are
+// also recorded in memory until the cache has caught up with them.
+type rateLimiter struct {
+	client  client.Client
+	limits  RateLimitSource
+	central string
+	now     func() time.Time
+
+	// mu serializes check-then-create so concurrent alerts cannot overrun a budget
+	mu     sync.Mutex
+	recent map[client.ObjectKey]k8shealerv1alpha1.Remediation
+}
+
+func newRateLimiter(cl client.Client, limits RateLimitSource, central string) *rateLimiter {
+	return &rateLimiter{
+		client:  cl,
+		limits:  limits,
+		central: central,
+		now:     time.Now,
+		recent:  map[client.ObjectKey]k8shealerv1alpha1.Remediation{},
+	}
+}
+
+// admit runs create for remediation if it fits the limits. Otherwise the
+// Remediation is marked throttled and created under a name of its own, or
+// nothing is created, depending on the OnLimit setting. The throttle reason
+// is returned.
+func (l *rateLimiter) admit(ctx context.Context, remediation *k8shealerv1alpha1.Remediation, create func() error) (string, error) {
+	limits := l.limits.RateLimits()
+	if !limits.Enabled() {
+		return "", create()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	reason, err := l.check(ctx, limits, &remediation.Spec)
+	if err != nil {
+		return "", err
+	}
+	if reason == "" {
+		if err := create(); err != nil {
+			return "", err
+		}
+		l.remember(remediation)
+		return "", nil
+	}
+
+	if limits.OnLimit == remediate.OnLimitSkip {
+		return reason, nil
+	}
+	if remediation.Annotations == nil {
+		remediation.Annotations = map[string]string{}
+	}
+	remediation.Annotations[remediate.AnnotationThrottled] = reason
+	remediation.Name = throttledName(remediation.Name, l.now())
+	return reason, create()
+}
+
+// preview returns the reason a Remediation for spec would be throttled, if any
+func (l *rateLimiter) preview(ctx context.Context, spec *k8shealerv1alpha1.RemediationSpec) (string, error) {
+	limits := l.limits.RateLimits()
+	if !limits.Enabled() {
+		return "", nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.check(ctx, limits, spec)
+}
+
+func (l *rateLimiter) check(ctx context.Context, limits remediate.RateLimits, spec *k8shealerv1alpha1.RemediationSpec) (string, error) {
+	existing, err := l.list(ctx, limits)
+	if err != nil {
+		return "", err
+	}
+	return limits.Check(spec, existing, l.now()), nil
+}
+
+// list returns the Remediations that may count against the limits
+func (l *rateLimiter) list(ctx context.Context, limits remediate.RateLimits) ([]k8shealerv1alpha1.Remediation, error) {
+	var opts []client.ListOption
+	if l.central != "" {
+		opts = append(opts, client.InNamespace(l.central))
+	}
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := l.client.List(ctx, list, opts...); err != nil {
+		return nil, fmt.Errorf("failed to list remediations for rate limiting: %w", err)
+	}
+
+	seen := make(map[client.ObjectKey]bool, len(list.Items))
+	for i := range list.Items {
+		seen[client.ObjectKeyFromObject(&list.Items[i])] = true
+	}
+
+	// Keep our own creates until they are old enough not to matter
+	horizon := max(limits.PerNamespace.Window, limits.Global.Window)
+	items := list.Items
+	for key, remediation := range l.recent {
+		if l.now().Sub(remediation.CreationTimestamp.Time) > horizon {
+			delete(l.recent, key)
+			continue
+		}
+		if !seen[key] {
+			items = append(items, remediation)
+		}
+	}
+	return items, nil
+}
+
+func (l *rateLimiter) remember(remediation *k8shealerv1alpha1.Remediation) {
+	record := *remediation.DeepCopy()
+	if record.CreationTimestamp.IsZero() {
+		record.CreationTimestamp = metav1.NewTime(l.now())
+	}
+	l.recent[client.ObjectKeyFromObject(remediation)] = record
+}
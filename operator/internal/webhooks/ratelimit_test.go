@@ -0,0 +1,215 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestProcessAlert_RateLimits(t *testing.T) {
+	tests := []struct {
+		name              string
+		onLimit           remediate.OnLimit
+		expectCreated     int
+		expectThrottled   int
+		expectRemediation int
+	}{
+		{
+			name:              "throttle",
+			onLimit:           remediate.OnLimitThrottle,
+			expectCreated:     2,
+			expectThrottled:   2,
+			expectRemediation: 4,
+		},
+		{
+			name:              "skip",
+			onLimit:           remediate.OnLimitSkip,
+			expectCreated:     2,
+			expectRemediation: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = k8shealerv1alpha1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+			handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{
+				RateLimits: StaticRateLimits{
+					PerNamespace: remediate.Budget{Max: 2, Window: time.Hour},
+					OnLimit:      tt.onLimit,
+				},
+			})
+
+			// Four different workloads in one namespace
+			for i := 0; i < 4; i++ {
+				err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, AlertPayload{
+					Status:      "firing",
+					Fingerprint: fmt.Sprintf("fp-%d", i),
+					StartsAt:    time.Now(),
+					Labels: map[string]string{
+						"alertname":  "KubePodOOMKilled",
+						"namespace":  "prod",
+						"deployment": fmt.Sprintf("api-%d", i),
+					},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			list := &k8shealerv1alpha1.RemediationList{}
+			if err := cl.List(context.Background(), list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Items) != tt.expectRemediation {
+				t.Fatalf("expected %d remediations, got %d", tt.expectRemediation, len(list.Items))
+			}
+			created, throttled := 0, 0
+			for i := range list.Items {
+				if remediate.IsThrottled(&list.Items[i]) {
+					throttled++
+				} else {
+					created++
+				}
+			}
+			if created != tt.expectCreated || throttled != tt.expectThrottled {
+				t.Errorf("expected %d created and %d throttled, got %d and %d",
+					tt.expectCreated, tt.expectThrottled, created, throttled)
+			}
+		})
+	}
+}
+
+func TestProcessAlert_Cooldown(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	appliedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	previous := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "rem-earlier",
+			Namespace:         "prod",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-20 * time.Minute)),
+		},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "prod"},
+			Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{
+			Phase:     k8shealerv1alpha1.RemediationPhaseSucceeded,
+			AppliedAt: &appliedAt,
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(previous).WithStatusSubresource(previous).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{
+		RateLimits: StaticRateLimits{Cooldown: time.Hour, OnLimit: remediate.OnLimitThrottle},
+	})
+
+	alert := AlertPayload{
+		Status:      "firing",
+		Fingerprint: "fp-refire",
+		StartsAt:    time.Now(),
+		Labels:      map[string]string{"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"},
+	}
+	if err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 remediations, got %d", len(list.Items))
+	}
+	for _, item := range list.Items {
+		if item.Name == previous.Name {
+			continue
+		}
+		if reason := item.Annotations[remediate.AnnotationThrottled]; reason == "" {
+			t.Errorf("expected the new remediation to be throttled, annotations: %v", item.Annotations)
+		}
+	}
+}
+
+func TestProcessAlert_RemediatesOnceCooldownPasses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	appliedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	previous := &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "rem-earlier",
+			Namespace:         "prod",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-20 * time.Minute)),
+		},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Target: k8shealerv1alpha1.TargetResource{Kind: "Deployment", Name: "api", Namespace: "prod"},
+			Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{
+			Phase:     k8shealerv1alpha1.RemediationPhaseSucceeded,
+			AppliedAt: &appliedAt,
+		},
+	}
+
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(previous).WithStatusSubresource(previous).Build()
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{
+		RateLimits: StaticRateLimits{Cooldown: time.Hour, OnLimit: remediate.OnLimitThrottle},
+	})
+
+	alert := AlertPayload{
+		Status:      "firing",
+		Fingerprint: "fp-still-firing",
+		StartsAt:    time.Now(),
+		Labels:      map[string]string{"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"},
+	}
+	if err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The same notification again once the cooldown is over
+	handler.rateLimiter.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, alert); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	created, throttled := 0, 0
+	for i := range list.Items {
+		if list.Items[i].Name == previous.Name {
+			continue
+		}
+		if remediate.IsThrottled(&list.Items[i]) {
+			throttled++
+		} else {
+			created++
+		}
+	}
+	if created != 1 || throttled != 1 {
+		t.Errorf("expected 1 created and 1 throttled remediation, got %d and %d", created, throttled)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+)
+
+// ConditionAlertResolved is set on a Remediation when its alert resolves
+const ConditionAlertResolved = "AlertResolved"
+
+// processResolvedAlert closes out Remediations created for an alert that has
+// since resolved. Remediations that were not applied yet are cancelled;
+// applied ones get an AlertResolved condition recording when the alert cleared.
+// A Remediation that other alerts were coalesced into is only closed out once
+// every one of them has resolved.
+func (h *AlertmanagerHandler) processResolvedAlert(ctx context.Context, alert AlertPayload) error {
+	logger := h.logger.WithValues(
+		"alertname", alert.Labels["alertname"],
+		"fingerprint", alert.Fingerprint,
+	)
+
+	if alert.Fingerprint == "" {
+		logger.Info("Resolved alert has no fingerprint, cannot match remediations")
+		return nil
+	}
+
+	remediations, err := remediationsForAlert(ctx, h.client, alert.Fingerprint, nil)
+	if err != nil {
+		return err
+	}
+
+	resolvedAt := alert.EndsAt
+	if resolvedAt.IsZero() {
+		resolvedAt = time.Now()
+	}
+
+	for i := range remediations {
+		key := client.ObjectKeyFromObject(&remediations[i])
+		if err := h.resolveRemediation(ctx, logger.WithValues("remediation", key), key, alert.Fingerprint, resolvedAt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (h *AlertmanagerHandler) resolveRemediation(ctx context.Context, logger logr.Logger, key client.ObjectKey, fingerprint string, resolvedAt time.Time) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		remediation := &k8shealerv1alpha1.Remediation{}
+		if err := h.client.Get(ctx, key, remediation); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+
+		if pending := unresolvedFingerprints(remediation, fingerprint); len(pending) > 0 {
+			if slices.Contains(remediation.Status.ResolvedFingerprints, fingerprint) {
+				return nil
+			}
+			remediation.Status.ResolvedFingerprints = append(remediation.Status.ResolvedFingerprints, fingerprint)
+			if err := h.client.Status().Update(ctx, remediation); err != nil {
+				return err
+			}
+			logger.Info("Coalesced alert resolved, others still firing", "unresolved", len(pending))
+			return nil
+		}
+
+		fromPhase := remediation.Status.Phase
+		var afterApply time.Duration
+		now := metav1.Now()
+		condition := metav1.Condition{
+			Type:               ConditionAlertResolved,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: remediation.Generation,
+			LastTransitionTime: metav1.NewTime(resolvedAt),
+		}
+
+		switch fromPhase {
+		case "",
+			k8shealerv1alpha1.RemediationPhasePending,
+			k8shealerv1alpha1.RemediationPhaseAnalyzing,
+			k8shealerv1alpha1.RemediationPhaseOnHold,
+			k8shealerv1alpha1.RemediationPhasePRCreated:
+			// Not applied yet: nothing left to fix
+			reason := fmt.Sprintf("Alert resolved at %s before the remediation was applied", resolvedAt.UTC().Format(time.RFC3339))
+			remediation.Status.Phase = k8shealerv1alpha1.RemediationPhaseCancelled
+			remediation.Status.Reason = reason
+			remediation.Status.ResolvedAt = &now
+			condition.Reason = "ResolvedBeforeApply"
+			condition.Message = reason
+
+		case k8shealerv1alpha1.RemediationPhaseApplying,
+			k8shealerv1alpha1.RemediationPhaseSucceeded:
+			if meta.IsStatusConditionTrue(remediation.Status.Conditions, ConditionAlertResolved) {
+				return nil
+			}
+			condition.Reason = "ResolvedAfterApply"
+			condition.Message = fmt.Sprintf("Alert resolved at %s", resolvedAt.UTC().Format(time.RFC3339))
+			if applied := remediation.Status.AppliedAt; applied != nil && !resolvedAt.Before(applied.Time) {
+				afterApply = resolvedAt.Sub(applied.Time)
+				condition.Message = fmt.Sprintf("Alert resolved at %s, %s after the remediation was applied",
+					resolvedAt.UTC().Format(time.RFC3339), afterApply.Round(time.Second))
+			}
+
+		default:
+			// Failed, Expired or already Cancelled
+			return nil
+		}
+
+		if !slices.Contains(remediation.Status.ResolvedFingerprints, fingerprint) {
+			remediation.Status.ResolvedFingerprints = append(remediation.Status.ResolvedFingerprints, fingerprint)
+		}
+		meta.SetStatusCondition(&remediation.Status.Conditions, condition)
+		remediation.Status.LastUpdateTime = &now
+
+		if err := h.client.Status().Update(ctx, remediation); err != nil {
+			return err
+		}
+
+		if afterApply > 0 {
+			metrics.AlertResolvedAfterApply.WithLabelValues(string(remediation.Spec.Action.Type)).Observe(afterApply.Seconds())
+		}
+		if remediation.Status.Phase != fromPhase {
+			metrics.RemediationPhaseTransitions.WithLabelValues(string(fromPhase), string(remediation.Status.Phase)).Inc()
+		}
+		logger.Info("Recorded resolved alert", "fromPhase", fromPhase, "phase", remediation.Status.Phase)
+		return nil
+	})
+}
+
+// unresolvedFingerprints returns the alerts contributing to remediation, other
+// than the one with fingerprint, that have not resolved yet
+func unresolvedFingerprints(remediation *k8shealerv1alpha1.Remediation, fingerprint string) []string {
+	var unresolved []string
+	for _, fp := range append([]string{remediation.Spec.Alert.Fingerprint}, remediation.Status.CoalescedFingerprints...) {
+		if fp != "" && fp != fingerprint && !slices.Contains(remediation.Status.ResolvedFingerprints, fp) {
+			unresolved = append(unresolved, fp)
+		}
+	}
+	return unresolved
+}
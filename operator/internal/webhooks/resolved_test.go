@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+)
+
+func newRemediation(name, fingerprint string, phase k8shealerv1alpha1.RemediationPhase) *k8shealerv1alpha1.Remediation {
+	return &k8shealerv1alpha1.Remediation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				"k8s-healer.io/fingerprint": fingerprint,
+			},
+		},
+		Spec: k8shealerv1alpha1.RemediationSpec{
+			Action: k8shealerv1alpha1.Action{Type: k8shealerv1alpha1.ActionTypeIncreaseMemory},
+		},
+		Status: k8shealerv1alpha1.RemediationStatus{
+			Phase: phase,
+		},
+	}
+}
+
+func TestProcessResolvedAlert(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+
+	appliedAt := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	pending := newRemediation("pending", "fp1", k8shealerv1alpha1.RemediationPhasePending)
+	onHold := newRemediation("on-hold", "fp1", k8shealerv1alpha1.RemediationPhaseOnHold)
+	prCreated := newRemediation("pr-created", "fp1", k8shealerv1alpha1.RemediationPhasePRCreated)
+	succeeded := newRemediation("succeeded", "fp1", k8shealerv1alpha1.RemediationPhaseSucceeded)
+	succeeded.Status.AppliedAt = &appliedAt
+	failed := newRemediation("failed", "fp1", k8shealerv1alpha1.RemediationPhaseFailed)
+	other := newRemediation("other", "fp2", k8shealerv1alpha1.RemediationPhasePending)
+
+	objs := []*k8shealerv1alpha1.Remediation{pending, onHold, prCreated, succeeded, failed, other}
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for _, obj := range objs {
+		builder = builder.WithObjects(obj).WithStatusSubresource(obj)
+	}
+	cl := builder.Build()
+
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{})
+
+	ctx := context.Background()
+	err := handler.processResolvedAlert(ctx, AlertPayload{
+		Status:      "resolved",
+		Labels:      map[string]string{"alertname": "KubePodOOMKilled"},
+		Fingerprint: "fp1",
+		EndsAt:      time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("processResolvedAlert failed: %v", err)
+	}
+
+	get := func(name string) *k8shealerv1alpha1.Remediation {
+		rem := &k8shealerv1alpha1.Remediation{}
+		if err := cl.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, rem); err != nil {
+			t.Fatalf("failed to get %s: %v", name, err)
+		}
+		return rem
+	}
+
+	for _, name := range []string{"pending", "on-hold", "pr-created"} {
+		rem := get(name)
+		if rem.Status.Phase != k8shealerv1alpha1.RemediationPhaseCancelled {
+			t.Errorf("%s: expected phase Cancelled, got %s", name, rem.Status.Phase)
+		}
+		if rem.Status.Reason == "" {
+			t.Errorf("%s: expected a cancellation reason", name)
+		}
+	}
+
+	rem := get("succeeded")
+	if rem.Status.Phase != k8shealerv1alpha1.RemediationPhaseSucceeded {
+		t.Errorf("succeeded: expected phase to stay Succeeded, got %s", rem.Status.Phase)
+	}
+	if !meta.IsStatusConditionTrue(rem.Status.Conditions, ConditionAlertResolved) {
+		t.Error("succeeded: expected AlertResolved condition")
+	}
+
+	if rem := get("failed"); rem.Status.Phase != k8shealerv1alpha1.RemediationPhaseFailed {
+		t.Errorf("failed: expected phase to stay Failed, got %s", rem.Status.Phase)
+	}
+	if rem := get("other"); rem.Status.Phase != k8shealerv1alpha1.RemediationPhasePending {
+		t.Errorf("other: expected unrelated remediation to stay Pending, got %s", rem.Status.Phase)
+	}
+}
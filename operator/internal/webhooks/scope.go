@@ -0,0 +1,100 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// checkScope decides whether a Remediation may be created for spec, based on
+// the operator's namespace scope and the heal8s.io annotations on the target
+// namespace and workload. It returns the reason if the target opted out. A
+// heal8s.io/mode annotation is applied to spec.Strategy and workload param
+// annotations are merged over the route's params.
+func (h *AlertmanagerHandler) checkScope(ctx context.Context, logger logr.Logger, spec *k8shealerv1alpha1.RemediationSpec) (string, error) {
+	scope := h.scope.Scope()
+	target := spec.Target
+
+	if !scope.AllowsNamespace(target.Namespace) {
+		return fmt.Sprintf("namespace %s is not in the operator's scope", target.Namespace), nil
+	}
+
+	namespace := &corev1.Namespace{}
+	namespaceAnnotations, err := h.annotationsOf(ctx, client.ObjectKey{Name: target.Namespace}, namespace)
+	if err != nil {
+		return "", err
+	}
+
+	var workloadAnnotations map[string]string
+	var workload client.Object = &corev1.Pod{}
+	if target.Kind != "Pod" {
+		workload, err = remediate.NewTargetObject(target.Kind)
+	}
+	if err == nil {
+		workloadAnnotations, err = h.annotationsOf(ctx, client.ObjectKey{Namespace: target.Namespace, Name: target.Name}, workload)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	decision, err := scope.EvaluateOptIn(namespaceAnnotations, workloadAnnotations, spec.Action.Type)
+	if err != nil {
+		// Fail closed: a malformed annotation should not widen what heal8s may change
+		return err.Error(), nil
+	}
+	if !decision.Allowed {
+		return decision.Reason, nil
+	}
+
+	params, err := remediate.MergeParamOverrides(spec.Action.Params, workloadAnnotations)
+	if err != nil {
+		// Fail closed as above: ignoring a bad override could exceed the intended cap
+		return err.Error(), nil
+	}
+	spec.Action.Params = params
+
+	if decision.Mode != "" && decision.Mode != spec.Strategy.Mode {
+		logger.Info("Strategy mode overridden by annotation", "mode", decision.Mode)
+		spec.Strategy.Mode = decision.Mode
+		if decision.Mode == k8shealerv1alpha1.StrategyModeDirect {
+			// Annotating a workload with mode Direct is the approval
+			spec.Strategy.RequireApproval = false
+		}
+	}
+
+	return "", nil
+}
+
+// annotationsOf returns the annotations of the object at key, or nil if it does not exist
+func (h *AlertmanagerHandler) annotationsOf(ctx context.Context, key client.ObjectKey, obj client.Object) (map[string]string, error) {
+	if err := h.client.Get(ctx, key, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+	return obj.GetAnnotations(), nil
+}
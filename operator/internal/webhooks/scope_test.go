@@ -0,0 +1,125 @@
+package webhooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+func TestProcessAlert_Scope(t *testing.T) {
+	tests := []struct {
+		name                 string
+		scope                remediate.Scope
+		namespaceAnnotations map[string]string
+		workloadAnnotations  map[string]string
+		expectCreated        bool
+		expectMode           k8shealerv1alpha1.StrategyMode
+		expectParams         map[string]string
+	}{
+		{
+			name:          "no annotations",
+			expectCreated: true,
+			expectMode:    k8shealerv1alpha1.StrategyModeGitOps,
+		},
+		{
+			name:  "namespace excluded by the operator",
+			scope: remediate.Scope{ExcludeNamespaces: []string{"prod"}},
+		},
+		{
+			name:                 "namespace opted out",
+			namespaceAnnotations: map[string]string{remediate.AnnotationEnabled: "false"},
+		},
+		{
+			name:                "action not allowed on workload",
+			workloadAnnotations: map[string]string{remediate.AnnotationActions: "ScaleUp"},
+		},
+		{
+			name:                 "workload opts in and requests direct mode",
+			scope:                remediate.Scope{RequireOptIn: true},
+			namespaceAnnotations: map[string]string{remediate.AnnotationMode: "Direct"},
+			workloadAnnotations:  map[string]string{remediate.AnnotationEnabled: "true"},
+			expectCreated:        true,
+			expectMode:           k8shealerv1alpha1.StrategyModeDirect,
+		},
+		{
+			name:                "workload param overrides",
+			workloadAnnotations: map[string]string{"heal8s.io/max-memory": "6Gi"},
+			expectCreated:       true,
+			expectMode:          k8shealerv1alpha1.StrategyModeGitOps,
+			expectParams: map[string]string{
+				"maxMemory":               "6Gi",
+				"memoryIncreasePercent":   "25",
+				remediate.ParamSourcesKey: "maxMemory=annotation,memoryIncreasePercent=route",
+			},
+		},
+		{
+			name:                "invalid param override fails closed",
+			workloadAnnotations: map[string]string{"heal8s.io/max-memory": "lots"},
+		},
+		{
+			name:                "malformed annotation fails closed",
+			workloadAnnotations: map[string]string{remediate.AnnotationMode: "Sometimes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = k8shealerv1alpha1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Annotations: tt.namespaceAnnotations}},
+				&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "prod", Annotations: tt.workloadAnnotations}},
+			).Build()
+			handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{Scope: StaticScope(tt.scope)})
+
+			err := handler.processAlert(context.Background(), remediate.SourceAlertmanager, AlertPayload{
+				Status:      "firing",
+				Fingerprint: "fp1",
+				StartsAt:    time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+				Labels:      map[string]string{"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			list := &k8shealerv1alpha1.RemediationList{}
+			if err := cl.List(context.Background(), list); err != nil {
+				t.Fatal(err)
+			}
+			if !tt.expectCreated {
+				if len(list.Items) != 0 {
+					t.Errorf("expected no remediation, got %d", len(list.Items))
+				}
+				return
+			}
+			if len(list.Items) != 1 {
+				t.Fatalf("expected 1 remediation, got %d", len(list.Items))
+			}
+			strategy := list.Items[0].Spec.Strategy
+			if strategy.Mode != tt.expectMode {
+				t.Errorf("expected mode %s, got %s", tt.expectMode, strategy.Mode)
+			}
+			if strategy.Mode == k8shealerv1alpha1.StrategyModeDirect && strategy.RequireApproval {
+				t.Error("expected direct mode annotation to drop the approval requirement")
+			}
+			for key, value := range tt.expectParams {
+				if got := list.Items[0].Spec.Action.Params[key]; got != value {
+					t.Errorf("param %s: expected %q, got %q", key, value, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 heal8s Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/heal8s/heal8s/operator/internal/metrics"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+// GrafanaWebhookPayload is the body of a Grafana Alerting webhook contact point
+type GrafanaWebhookPayload struct {
+	Receiver          string            `json:"receiver"`
+	Status            string            `json:"status"`
+	OrgID             int64             `json:"orgId"`
+	Alerts            []GrafanaAlert    `json:"alerts"`
+	GroupLabels       map[string]string `json:"groupLabels"`
+	CommonLabels      map[string]string `json:"commonLabels"`
+	CommonAnnotations map[string]string `json:"commonAnnotations"`
+	ExternalURL       string            `json:"externalURL"`
+	Version           string            `json:"version"`
+	GroupKey          string            `json:"groupKey"`
+	TruncatedAlerts   int               `json:"truncatedAlerts"`
+	Title             string            `json:"title"`
+	State             string            `json:"state"`
+	Message           string            `json:"message"`
+}
+
+// GrafanaAlert is a single alert in a Grafana Alerting notification
+type GrafanaAlert struct {
+	Status       string                 `json:"status"`
+	Labels       map[string]string      `json:"labels"`
+	Annotations  map[string]string      `json:"annotations"`
+	StartsAt     time.Time              `json:"startsAt"`
+	EndsAt       time.Time              `json:"endsAt"`
+	Values       map[string]interface{} `json:"values"`
+	GeneratorURL string                 `json:"generatorURL"`
+	Fingerprint  string                 `json:"fingerprint"`
+	SilenceURL   string                 `json:"silenceURL"`
+	DashboardURL string                 `json:"dashboardURL"`
+	PanelURL     string                 `json:"panelURL"`
+}
+
+// GenericWebhookPayload is the documented JSON schema for custom alert sources:
+//
+//	{"alerts": [{"status": "firing", "labels": {"alertname": "...", "namespace": "...", "deployment": "..."},
+//	  "annotations": {}, "fingerprint": "...", "startsAt": "2026-01-02T15:04:05Z"}]}
+type GenericWebhookPayload struct {
+	Alerts []GenericAlert `json:"alerts"`
+}
+
+// GenericAlert is a single alert in the generic schema. Status defaults to
+// firing and the fingerprint, if omitted, is derived from the labels.
+// Firing alerts must set startsAt, which identifies the alert instance for
+// deduplication.
+type GenericAlert struct {
+	Status      string            `json:"status"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Fingerprint string            `json:"fingerprint"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+}
+
+// HandleGrafanaWebhook handles Grafana Alerting webhook contact point requests
+func (h *AlertmanagerHandler) HandleGrafanaWebhook(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	var payload GrafanaWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.logger.Error(err, "Failed to parse Grafana webhook payload")
+		metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+	h.logger.Info("Received Grafana webhook",
+		"receiver", payload.Receiver,
+		"status", payload.Status,
+		"alertCount", len(payload.Alerts))
+
+	alerts := make([]AlertPayload, 0, len(payload.Alerts))
+	for _, alert := range payload.Alerts {
+		fingerprint := alert.Fingerprint
+		if fingerprint == "" {
+			fingerprint = Fingerprint(alert.Labels)
+		} else if len(validation.IsValidLabelValue(fingerprint)) > 0 {
+			// Grafana sends hex fingerprints; anything else is hashed so it
+			// can still be stored and looked up as a label value
+			fingerprint = Fingerprint(map[string]string{"fingerprint": fingerprint})
+		}
+		alerts = append(alerts, AlertPayload{
+			Status:       alert.Status,
+			Labels:       alert.Labels,
+			Annotations:  alert.Annotations,
+			StartsAt:     alert.StartsAt,
+			EndsAt:       alert.EndsAt,
+			GeneratorURL: alert.GeneratorURL,
+			Fingerprint:  fingerprint,
+		})
+	}
+
+	h.enqueueAlerts(w, remediate.SourceGrafana, alerts)
+}
+
+// HandleGenericWebhook handles alerts posted in the generic JSON schema
+func (h *AlertmanagerHandler) HandleGenericWebhook(w http.ResponseWriter, r *http.Request) {
+	body, ok := h.readBody(w, r)
+	if !ok {
+		return
+	}
+
+	var payload GenericWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		h.logger.Error(err, "Failed to parse generic webhook payload")
+		metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	alerts := make([]AlertPayload, 0, len(payload.Alerts))
+	for i, alert := range payload.Alerts {
+		converted, err := alert.toAlertPayload()
+		if err != nil {
+			metrics.WebhookRequestsRejected.WithLabelValues("bad-request").Inc()
+			http.Error(w, fmt.Sprintf("Invalid alert %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+		alerts = append(alerts, converted)
+	}
+	h.logger.Info("Received generic webhook", "alertCount", len(alerts))
+
+	h.enqueueAlerts(w, remediate.SourceGeneric, alerts)
+}
+
+func (a GenericAlert) toAlertPayload() (AlertPayload, error) {
+	status := a.Status
+	if status == "" {
+		status = "firing"
+	}
+	if status != "firing" && status != "resolved" {
+		return AlertPayload{}, fmt.Errorf("status must be firing or resolved, got %q", status)
+	}
+	if a.Labels["alertname"] == "" {
+		return AlertPayload{}, fmt.Errorf("labels.alertname is required")
+	}
+	if status == "firing" && a.StartsAt.IsZero() {
+		return AlertPayload{}, fmt.Errorf("startsAt is required for firing alerts")
+	}
+
+	fingerprint := a.Fingerprint
+	if fingerprint == "" {
+		fingerprint = Fingerprint(a.Labels)
+	}
+	// Fingerprints are stored and looked up as label values
+	if errs := validation.IsValidLabelValue(fingerprint); len(errs) > 0 {
+		return AlertPayload{}, fmt.Errorf("invalid fingerprint %q: %s", fingerprint, strings.Join(errs, "; "))
+	}
+
+	return AlertPayload{
+		Status:      status,
+		Labels:      a.Labels,
+		Annotations: a.Annotations,
+		StartsAt:    a.StartsAt,
+		EndsAt:      a.EndsAt,
+		Fingerprint: fingerprint,
+	}, nil
+}
+
+// Fingerprint hashes a sorted label set into a stable identifier, for alert
+// sources that do not provide one
+func Fingerprint(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := fnv.New64a()
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0xff})
+		h.Write([]byte(labels[name]))
+		h.Write([]byte{0xff})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
@@ -0,0 +1,189 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	k8shealerv1alpha1 "github.com/heal8s/heal8s/operator/api/v1alpha1"
+	"github.com/heal8s/heal8s/operator/internal/remediate"
+)
+
+const grafanaPayload = `{
+  "receiver": "heal8s",
+  "status": "firing",
+  "orgId": 1,
+  "alerts": [{
+    "status": "firing",
+    "labels": {"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api", "severity": "critical"},
+    "annotations": {"summary": "api OOM killed"},
+    "startsAt": "2026-01-02T15:04:05Z",
+    "endsAt": "0001-01-01T00:00:00Z",
+    "values": {"B": 3, "C": 1},
+    "fingerprint": "0a1b2c3d4e5f6789"
+  }],
+  "version": "1",
+  "title": "[FIRING:1] KubePodOOMKilled"
+}`
+
+func TestHandleSourceWebhooks(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		grafana      bool
+		expectStatus int
+		expectSource string
+	}{
+		{
+			name:         "grafana alert",
+			body:         grafanaPayload,
+			grafana:      true,
+			expectStatus: http.StatusOK,
+			expectSource: remediate.SourceGrafana,
+		},
+		{
+			name: "generic alert",
+			body: `{"alerts": [{"labels": {"alertname": "KubePodOOMKilled", "namespace": "prod", "deployment": "api"},
+			  "startsAt": "2026-01-02T15:04:05Z"}]}`,
+			expectStatus: http.StatusOK,
+			expectSource: remediate.SourceGeneric,
+		},
+		{
+			name:         "generic alert without alertname",
+			body:         `{"alerts": [{"labels": {"namespace": "prod"}, "startsAt": "2026-01-02T15:04:05Z"}]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "generic firing alert without startsAt",
+			body:         `{"alerts": [{"labels": {"alertname": "KubePodOOMKilled"}}]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name: "generic alert with a fingerprint that is not a label value",
+			body: `{"alerts": [{"labels": {"alertname": "KubePodOOMKilled"}, "fingerprint": "api/oom killed",
+			  "startsAt": "2026-01-02T15:04:05Z"}]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+		{
+			name:         "generic alert with unknown status",
+			body:         `{"alerts": [{"status": "pending", "labels": {"alertname": "KubePodOOMKilled"}, "startsAt": "2026-01-02T15:04:05Z"}]}`,
+			expectStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = k8shealerv1alpha1.AddToScheme(scheme)
+			_ = appsv1.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+			cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+			handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{})
+
+			serve := handler.HandleGenericWebhook
+			if tt.grafana {
+				serve = handler.HandleGrafanaWebhook
+			}
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/test", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			serve(rec, req)
+
+			if rec.Code != tt.expectStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectStatus, rec.Code, rec.Body.String())
+			}
+			if tt.expectStatus != http.StatusOK {
+				return
+			}
+
+			// Run the queued work inline and check the created Remediation
+			if len(handler.queue.items) != 1 {
+				t.Fatalf("expected 1 queued alert, got %d", len(handler.queue.items))
+			}
+			task := <-handler.queue.items
+			if err := task.process(context.Background()); err != nil {
+				t.Fatalf("unexpected processing error: %v", err)
+			}
+
+			list := &k8shealerv1alpha1.RemediationList{}
+			if err := cl.List(context.Background(), list); err != nil {
+				t.Fatal(err)
+			}
+			if len(list.Items) != 1 {
+				t.Fatalf("expected 1 remediation, got %d", len(list.Items))
+			}
+			rem := list.Items[0]
+			if rem.Spec.Alert.Source != tt.expectSource {
+				t.Errorf("expected source %s, got %s", tt.expectSource, rem.Spec.Alert.Source)
+			}
+			if rem.Spec.Alert.Fingerprint == "" {
+				t.Error("expected a fingerprint")
+			}
+			if rem.Spec.Target.Kind != "Deployment" || rem.Spec.Target.Name != "api" {
+				t.Errorf("unexpected target %s/%s", rem.Spec.Target.Kind, rem.Spec.Target.Name)
+			}
+		})
+	}
+}
+
+func TestHandleGrafanaWebhook_RuleTitleAsAlertname(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = k8shealerv1alpha1.AddToScheme(scheme)
+	_ = appsv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	routes := remediate.RouterConfig{Rules: []remediate.RouteRule{{
+		Name:       "memory",
+		ActionType: remediate.ActionTypeIncreaseMemory,
+		Params:     map[string]string{"memoryIncreasePercent": "25", "maxMemory": "2Gi"},
+	}}}
+	handler := NewAlertmanagerHandler(cl, scheme, logr.Discard(), HandlerOptions{Routes: StaticRouterConfig(routes)})
+
+	// Grafana uses the rule title as the alertname
+	body := `{"receiver": "heal8s", "status": "firing", "alerts": [{
+	  "status": "firing",
+	  "labels": {"alertname": "High memory usage", "namespace": "prod", "deployment": "api"},
+	  "startsAt": "2026-01-02T15:04:05Z",
+	  "fingerprint": "rule 7/instance api"
+	}]}`
+	rec := httptest.NewRecorder()
+	handler.HandleGrafanaWebhook(rec, httptest.NewRequest(http.MethodPost, "/webhooks/grafana", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	task := <-handler.queue.items
+	if err := task.process(context.Background()); err != nil {
+		t.Fatalf("unexpected processing error: %v", err)
+	}
+
+	list := &k8shealerv1alpha1.RemediationList{}
+	if err := cl.List(context.Background(), list); err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected 1 remediation, got %d", len(list.Items))
+	}
+	rem := list.Items[0]
+	for key, value := range rem.Labels {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			t.Errorf("label %s has invalid value %q: %v", key, value, errs)
+		}
+	}
+	if got := rem.Labels["k8s-healer.io/alert"]; got != "High-memory-usage" {
+		t.Errorf("expected alert label High-memory-usage, got %q", got)
+	}
+	if rem.Spec.Alert.Name != "High memory usage" {
+		t.Errorf("expected the alert name to be kept, got %q", rem.Spec.Alert.Name)
+	}
+	if !strings.HasPrefix(rem.Name, "rem-high-memory-usage-") {
+		t.Errorf("expected a name derived from the title, got %q", rem.Name)
+	}
+}